@@ -0,0 +1,167 @@
+package cache
+
+import xmap "github.com/danielhookx/xcontainer/map"
+
+// ARC is an adaptive replacement cache (Megiddo and Modha).
+// It splits its entries between those used once recently (t1) and those used at least
+// twice (t2), and remembers the keys recently evicted from each (the ghost lists b1 and b2).
+// A hit on a ghost key shows which side was evicted too early, and moves the target
+// size p of t1 accordingly, so the cache adapts between recency and frequency.
+// Each of the four lists is an OrderedMap whose insertion order runs from least to most
+// recently used. Every operation runs in O(1).
+type ARC[K comparable, V any] struct {
+	t1, t2  *xmap.OrderedMap[K, V]
+	b1, b2  *xmap.OrderedMap[K, struct{}]
+	p       int // target size of t1
+	cap     int
+	onEvict EvictFunc[K, V]
+}
+
+var _ Cache[int, int] = (*ARC[int, int])(nil)
+
+// NewARC returns an empty ARC cache holding at most capacity entries.
+// It also remembers up to capacity keys of evicted entries.
+// It panics if capacity is less than 1.
+func NewARC[K comparable, V any](capacity int) *ARC[K, V] {
+	checkCapacity(capacity)
+	return &ARC[K, V]{
+		t1:  xmap.NewOrderedMap[K, V](),
+		t2:  xmap.NewOrderedMap[K, V](),
+		b1:  xmap.NewOrderedMap[K, struct{}](),
+		b2:  xmap.NewOrderedMap[K, struct{}](),
+		cap: capacity,
+	}
+}
+
+// OnEvict sets the function called for every evicted entry.
+func (c *ARC[K, V]) OnEvict(fn EvictFunc[K, V]) {
+	c.onEvict = fn
+}
+
+// Get returns the value stored for key and records the access,
+// promoting the entry to the frequently used side.
+// The boolean is false if key is absent.
+func (c *ARC[K, V]) Get(key K) (V, bool) {
+	if v, ok := c.t1.Get(key); ok {
+		c.t1.Delete(key)
+		c.t2.Set(key, v)
+		return v, true
+	}
+	if v, ok := c.t2.Get(key); ok {
+		c.t2.Delete(key)
+		c.t2.Set(key, v)
+		return v, true
+	}
+	return *new(V), false
+}
+
+// Put stores value for key and records the access.
+// If the cache is full, an entry is evicted from the side that exceeds its target size.
+func (c *ARC[K, V]) Put(key K, value V) {
+	if c.t1.Delete(key) || c.t2.Delete(key) {
+		c.t2.Set(key, value)
+		return
+	}
+
+	if _, ok := c.b1.Get(key); ok {
+		// evicted from t1 too early: favor recency
+		c.p = min(c.cap, c.p+max(c.b2.Len()/c.b1.Len(), 1))
+		c.replace(false)
+		c.b1.Delete(key)
+		c.t2.Set(key, value)
+		return
+	}
+	if _, ok := c.b2.Get(key); ok {
+		// evicted from t2 too early: favor frequency
+		c.p = max(0, c.p-max(c.b1.Len()/c.b2.Len(), 1))
+		c.replace(true)
+		c.b2.Delete(key)
+		c.t2.Set(key, value)
+		return
+	}
+
+	if c.t1.Len()+c.b1.Len() >= c.cap {
+		if c.t1.Len() < c.cap {
+			c.dropGhost(c.b1)
+			c.replace(false)
+		} else {
+			// b1 is empty and t1 fills the cache: drop its oldest entry without a ghost
+			k, v, _ := oldest(c.t1)
+			c.t1.Delete(k)
+			c.evicted(k, v)
+		}
+	} else if total := c.t1.Len() + c.t2.Len() + c.b1.Len() + c.b2.Len(); total >= c.cap {
+		if total >= 2*c.cap {
+			c.dropGhost(c.b2)
+		}
+		c.replace(false)
+	}
+	c.t1.Set(key, value)
+}
+
+// Peek returns the value stored for key without recording an access.
+// The boolean is false if key is absent.
+func (c *ARC[K, V]) Peek(key K) (V, bool) {
+	if v, ok := c.t1.Get(key); ok {
+		return v, true
+	}
+	return c.t2.Get(key)
+}
+
+// Remove deletes key from the cache and reports whether it was present.
+// The key is also forgotten by the ghost lists.
+func (c *ARC[K, V]) Remove(key K) bool {
+	c.b1.Delete(key)
+	c.b2.Delete(key)
+	return c.t1.Delete(key) || c.t2.Delete(key)
+}
+
+// Len returns the number of entries in the cache.
+func (c *ARC[K, V]) Len() int {
+	return c.t1.Len() + c.t2.Len()
+}
+
+// Cap returns the maximum number of entries in the cache.
+func (c *ARC[K, V]) Cap() int {
+	return c.cap
+}
+
+// Clear removes all entries from the cache and resets its adaptation.
+func (c *ARC[K, V]) Clear() {
+	c.t1 = xmap.NewOrderedMap[K, V]()
+	c.t2 = xmap.NewOrderedMap[K, V]()
+	c.b1 = xmap.NewOrderedMap[K, struct{}]()
+	c.b2 = xmap.NewOrderedMap[K, struct{}]()
+	c.p = 0
+}
+
+// replace makes room for one entry if the cache is full, moving the oldest entry
+// of t1 or t2 to its ghost list. inB2 tells whether the incoming key was found in b2.
+func (c *ARC[K, V]) replace(inB2 bool) {
+	if c.t1.Len()+c.t2.Len() < c.cap {
+		return
+	}
+	if c.t2.Len() == 0 || c.t1.Len() > 0 && (c.t1.Len() > c.p || inB2 && c.t1.Len() == c.p) {
+		k, v, _ := oldest(c.t1)
+		c.t1.Delete(k)
+		c.b1.Set(k, struct{}{})
+		c.evicted(k, v)
+		return
+	}
+	k, v, _ := oldest(c.t2)
+	c.t2.Delete(k)
+	c.b2.Set(k, struct{}{})
+	c.evicted(k, v)
+}
+
+func (c *ARC[K, V]) dropGhost(b *xmap.OrderedMap[K, struct{}]) {
+	if k, _, ok := oldest(b); ok {
+		b.Delete(k)
+	}
+}
+
+func (c *ARC[K, V]) evicted(key K, value V) {
+	if c.onEvict != nil {
+		c.onEvict(key, value)
+	}
+}
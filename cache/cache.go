@@ -0,0 +1,42 @@
+// Package cache provides fixed-capacity key-value caches with LRU, LFU and ARC eviction policies.
+// The caches are not safe for concurrent use; callers must synchronize access if needed.
+package cache
+
+import xmap "github.com/danielhookx/xcontainer/map"
+
+// Cache is the interface shared by the caches of this package.
+type Cache[K comparable, V any] interface {
+	// Get returns the value stored for key and records the access.
+	Get(key K) (V, bool)
+	// Put stores value for key, evicting an entry if the cache is full.
+	Put(key K, value V)
+	// Peek returns the value stored for key without recording an access.
+	Peek(key K) (V, bool)
+	// Remove deletes key from the cache and reports whether it was present.
+	Remove(key K) bool
+	// Len returns the number of entries in the cache.
+	Len() int
+	// Cap returns the maximum number of entries in the cache.
+	Cap() int
+	// Clear removes all entries from the cache.
+	Clear()
+}
+
+// EvictFunc is called with the key and value of every entry a cache evicts,
+// either to make room or because the entry expired. It is not called for Remove and Clear.
+type EvictFunc[K comparable, V any] func(key K, value V)
+
+// oldest returns the first key-value pair of m in insertion order.
+// The boolean is false if m is empty.
+func oldest[K comparable, V any](m *xmap.OrderedMap[K, V]) (K, V, bool) {
+	for k, v := range m.Iter() {
+		return k, v, true
+	}
+	return *new(K), *new(V), false
+}
+
+func checkCapacity(capacity int) {
+	if capacity < 1 {
+		panic("cache: capacity must be positive")
+	}
+}
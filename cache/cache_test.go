@@ -0,0 +1,266 @@
+package cache
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type evictLog[K comparable, V any] struct {
+	keys []K
+}
+
+func (l *evictLog[K, V]) record(key K, _ V) {
+	l.keys = append(l.keys, key)
+}
+
+func TestLRU(t *testing.T) {
+	c := NewLRU[string, int](2)
+	log := &evictLog[string, int]{}
+	c.OnEvict(log.record)
+	assert.Equal(t, 2, c.Cap())
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	v, ok := c.Get("a") // b is now the least recently used
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	k, _, _ := c.Oldest()
+	assert.Equal(t, "b", k)
+
+	c.Put("c", 3)
+	assert.EqualValues(t, []string{"b"}, log.keys)
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+
+	// Peek does not refresh recency
+	v, ok = c.Peek("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	c.Put("d", 4)
+	assert.EqualValues(t, []string{"b", "a"}, log.keys)
+
+	// updating an entry refreshes it without evicting
+	c.Put("c", 30)
+	c.Put("e", 5)
+	assert.EqualValues(t, []string{"b", "a", "d"}, log.keys)
+	v, _ = c.Get("c")
+	assert.Equal(t, 30, v)
+
+	assert.True(t, c.Remove("c"))
+	assert.False(t, c.Remove("c"))
+	assert.Equal(t, 1, c.Len())
+	c.Clear()
+	assert.Equal(t, 0, c.Len())
+	_, _, ok = c.Oldest()
+	assert.False(t, ok)
+	assert.Len(t, log.keys, 3)
+
+	assert.Panics(t, func() { NewLRU[int, int](0) })
+}
+
+func TestLRUTTL(t *testing.T) {
+	now := time.Unix(1000, 0)
+	c := NewLRU[string, int](10)
+	c.now = func() time.Time { return now }
+	log := &evictLog[string, int]{}
+	c.OnEvict(log.record)
+
+	c.Put("forever", 0)
+	c.SetTTL(time.Minute)
+	c.Put("a", 1)
+	now = now.Add(30 * time.Second)
+	c.Put("b", 2)
+
+	now = now.Add(40 * time.Second)
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+	assert.EqualValues(t, []string{"a"}, log.keys)
+	v, ok := c.Peek("b")
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+
+	// putting again restarts the time to live
+	c.Put("b", 3)
+	now = now.Add(50 * time.Second)
+	v, ok = c.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+	now = now.Add(10 * time.Second)
+	_, ok = c.Peek("b")
+	assert.False(t, ok)
+
+	_, ok = c.Get("forever")
+	assert.True(t, ok)
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestLFU(t *testing.T) {
+	c := NewLFU[string, int](3)
+	log := &evictLog[string, int]{}
+	c.OnEvict(log.record)
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	c.Get("a")
+	c.Get("a")
+	c.Get("b")
+	assert.Equal(t, 3, c.Count("a"))
+	assert.Equal(t, 2, c.Count("b"))
+	assert.Equal(t, 1, c.Count("c"))
+
+	c.Put("d", 4) // c is used least
+	assert.EqualValues(t, []string{"c"}, log.keys)
+	c.Put("e", 5) // d and e tie at one use; d got there first
+	assert.EqualValues(t, []string{"c", "d"}, log.keys)
+
+	// Peek does not count a use
+	v, ok := c.Peek("e")
+	assert.True(t, ok)
+	assert.Equal(t, 5, v)
+	assert.Equal(t, 1, c.Count("e"))
+
+	// updating counts a use
+	c.Put("e", 50)
+	c.Put("e", 51)
+	c.Put("f", 6) // b has 2 uses, e 3, a 3
+	assert.EqualValues(t, []string{"c", "d", "b"}, log.keys)
+	v, _ = c.Get("e")
+	assert.Equal(t, 51, v)
+
+	assert.True(t, c.Remove("a"))
+	assert.False(t, c.Remove("a"))
+	assert.Equal(t, 0, c.Count("a"))
+	assert.Equal(t, 2, c.Len())
+	c.Clear()
+	assert.Equal(t, 0, c.Len())
+	c.Put("g", 7)
+	assert.Equal(t, 1, c.Count("g"))
+}
+
+func TestARC(t *testing.T) {
+	c := NewARC[int, int](4)
+	log := &evictLog[int, int]{}
+	c.OnEvict(log.record)
+
+	for i := 0; i < 4; i++ {
+		c.Put(i, i*10)
+	}
+	// 0 and 1 become frequently used
+	c.Get(0)
+	c.Get(1)
+	for i := 4; i < 8; i++ {
+		c.Put(i, i*10)
+	}
+	// a scan of keys used once does not flush the frequently used ones
+	for _, k := range []int{0, 1} {
+		v, ok := c.Peek(k)
+		assert.True(t, ok)
+		assert.Equal(t, k*10, v)
+	}
+	assert.Equal(t, 4, c.Len())
+	assert.Len(t, log.keys, 4)
+
+	// a ghost hit brings the key back as frequently used
+	c.Put(2, 20)
+	v, ok := c.Get(2)
+	assert.True(t, ok)
+	assert.Equal(t, 20, v)
+	assert.Equal(t, 4, c.Len())
+
+	assert.True(t, c.Remove(2))
+	assert.False(t, c.Remove(2))
+	assert.Equal(t, 3, c.Len())
+	c.Clear()
+	assert.Equal(t, 0, c.Len())
+}
+
+// TestCacheModel drives every cache with random operations and checks it against a plain map:
+// a cache may forget entries, but never return a stale value or outgrow its capacity.
+func TestCacheModel(t *testing.T) {
+	const capacity, keys = 16, 64
+	caches := map[string]Cache[int, int]{
+		"lru": NewLRU[int, int](capacity),
+		"lfu": NewLFU[int, int](capacity),
+		"arc": NewARC[int, int](capacity),
+	}
+	for name, c := range caches {
+		latest := make(map[int]int)
+		for i := 0; i < 20000; i++ {
+			k := rand.Intn(keys)
+			switch rand.Intn(4) {
+			case 0, 1:
+				c.Put(k, i)
+				latest[k] = i
+			case 2:
+				if v, ok := c.Get(k); ok {
+					assert.Equal(t, latest[k], v, name)
+				}
+			default:
+				if rand.Intn(8) == 0 {
+					c.Remove(k)
+				} else if v, ok := c.Peek(k); ok {
+					assert.Equal(t, latest[k], v, name)
+				}
+			}
+			if c.Len() > capacity {
+				t.Fatalf("%s: %d entries exceed the capacity", name, c.Len())
+			}
+		}
+		if arc, ok := c.(*ARC[int, int]); ok {
+			assert.LessOrEqual(t, arc.t1.Len()+arc.b1.Len(), capacity)
+			assert.LessOrEqual(t, arc.Len()+arc.b1.Len()+arc.b2.Len(), 2*capacity)
+		}
+	}
+}
+
+// hitRate replays a workload of hot keys interleaved with one-off scans.
+func hitRate(c Cache[int, int]) float64 {
+	r := rand.New(rand.NewSource(1))
+	hits, gets := 0, 0
+	scan := 1000
+	for i := 0; i < 50000; i++ {
+		k := r.Intn(50)
+		if i%3 == 0 {
+			k = scan
+			scan++
+		}
+		gets++
+		if _, ok := c.Get(k); ok {
+			hits++
+			continue
+		}
+		c.Put(k, k)
+	}
+	return float64(hits) / float64(gets)
+}
+
+func TestHitRate(t *testing.T) {
+	lru := hitRate(NewLRU[int, int](40))
+	arc := hitRate(NewARC[int, int](40))
+	lfu := hitRate(NewLFU[int, int](40))
+	t.Logf("hit rate: lru %.3f, lfu %.3f, arc %.3f", lru, lfu, arc)
+	assert.Greater(t, arc, lru)
+}
+
+func benchmarkCache(b *testing.B, c Cache[int, int]) {
+	r := rand.New(rand.NewSource(1))
+	keys := make([]int, 1<<16)
+	for i := range keys {
+		keys[i] = int(r.ExpFloat64() * 1000)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		k := keys[i&(len(keys)-1)]
+		if _, ok := c.Get(k); !ok {
+			c.Put(k, k)
+		}
+	}
+}
+
+func BenchmarkLRU(b *testing.B) { benchmarkCache(b, NewLRU[int, int](1000)) }
+func BenchmarkLFU(b *testing.B) { benchmarkCache(b, NewLFU[int, int](1000)) }
+func BenchmarkARC(b *testing.B) { benchmarkCache(b, NewARC[int, int](1000)) }
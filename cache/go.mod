@@ -0,0 +1,16 @@
+module github.com/danielhookx/xcontainer/cache
+
+go 1.23.4
+
+require (
+	github.com/danielhookx/xcontainer/list v1.0.0
+	github.com/danielhookx/xcontainer/map v1.0.0
+	github.com/stretchr/testify v1.10.0
+)
+
+require (
+	github.com/danielhookx/xcontainer/set v1.0.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,156 @@
+package cache
+
+import (
+	"github.com/danielhookx/xcontainer/list"
+	xmap "github.com/danielhookx/xcontainer/map"
+)
+
+// LFU is a cache that evicts the least frequently used entry when full.
+// Among entries used equally often, the one that reached that count first is evicted.
+// Every operation runs in O(1): entries are kept in buckets of equal use count,
+// and the buckets in a list ordered by count.
+type LFU[K comparable, V any] struct {
+	buckets *list.List[*lfuBucket[K]] // ascending count
+	items   map[K]*lfuEntry[K, V]
+	cap     int
+	onEvict EvictFunc[K, V]
+}
+
+type lfuBucket[K comparable] struct {
+	count int
+	keys  *xmap.OrderedMap[K, struct{}] // in the order they reached count
+}
+
+type lfuEntry[K comparable, V any] struct {
+	value  V
+	bucket *list.Element[*lfuBucket[K]]
+}
+
+var _ Cache[int, int] = (*LFU[int, int])(nil)
+
+// NewLFU returns an empty LFU cache holding at most capacity entries.
+// It panics if capacity is less than 1.
+func NewLFU[K comparable, V any](capacity int) *LFU[K, V] {
+	checkCapacity(capacity)
+	return &LFU[K, V]{
+		buckets: list.New[*lfuBucket[K]](),
+		items:   make(map[K]*lfuEntry[K, V]),
+		cap:     capacity,
+	}
+}
+
+// OnEvict sets the function called for every evicted entry.
+func (c *LFU[K, V]) OnEvict(fn EvictFunc[K, V]) {
+	c.onEvict = fn
+}
+
+// Get returns the value stored for key and counts one use of the entry.
+// The boolean is false if key is absent.
+func (c *LFU[K, V]) Get(key K) (V, bool) {
+	e, ok := c.items[key]
+	if !ok {
+		return *new(V), false
+	}
+	c.touch(key, e)
+	return e.value, true
+}
+
+// Put stores value for key and counts one use of the entry.
+// If the cache is full, the least frequently used entry is evicted.
+func (c *LFU[K, V]) Put(key K, value V) {
+	if e, ok := c.items[key]; ok {
+		e.value = value
+		c.touch(key, e)
+		return
+	}
+	if len(c.items) >= c.cap {
+		c.evict()
+	}
+	first := c.buckets.Front()
+	if first == nil || first.Value.count != 1 {
+		first = c.buckets.PushFront(newLFUBucket[K](1))
+	}
+	first.Value.keys.Set(key, struct{}{})
+	c.items[key] = &lfuEntry[K, V]{value: value, bucket: first}
+}
+
+// Peek returns the value stored for key without counting a use.
+// The boolean is false if key is absent.
+func (c *LFU[K, V]) Peek(key K) (V, bool) {
+	e, ok := c.items[key]
+	if !ok {
+		return *new(V), false
+	}
+	return e.value, true
+}
+
+// Remove deletes key from the cache and reports whether it was present.
+func (c *LFU[K, V]) Remove(key K) bool {
+	e, ok := c.items[key]
+	if ok {
+		c.unlink(key, e.bucket)
+		delete(c.items, key)
+	}
+	return ok
+}
+
+// Count returns how many times key has been used since it was put in the cache.
+// It returns 0 if key is absent.
+func (c *LFU[K, V]) Count(key K) int {
+	e, ok := c.items[key]
+	if !ok {
+		return 0
+	}
+	return e.bucket.Value.count
+}
+
+// Len returns the number of entries in the cache.
+func (c *LFU[K, V]) Len() int {
+	return len(c.items)
+}
+
+// Cap returns the maximum number of entries in the cache.
+func (c *LFU[K, V]) Cap() int {
+	return c.cap
+}
+
+// Clear removes all entries from the cache.
+func (c *LFU[K, V]) Clear() {
+	c.buckets.Init()
+	clear(c.items)
+}
+
+func newLFUBucket[K comparable](count int) *lfuBucket[K] {
+	return &lfuBucket[K]{count: count, keys: xmap.NewOrderedMap[K, struct{}]()}
+}
+
+// touch moves key to the bucket of the next count.
+func (c *LFU[K, V]) touch(key K, e *lfuEntry[K, V]) {
+	cur := e.bucket
+	next := cur.Next()
+	if next == nil || next.Value.count != cur.Value.count+1 {
+		next = c.buckets.InsertAfter(newLFUBucket[K](cur.Value.count+1), cur)
+	}
+	next.Value.keys.Set(key, struct{}{})
+	c.unlink(key, cur)
+	e.bucket = next
+}
+
+// unlink removes key from bucket b, dropping the bucket once empty.
+func (c *LFU[K, V]) unlink(key K, b *list.Element[*lfuBucket[K]]) {
+	b.Value.keys.Delete(key)
+	if b.Value.keys.Len() == 0 {
+		c.buckets.Remove(b)
+	}
+}
+
+func (c *LFU[K, V]) evict() {
+	b := c.buckets.Front()
+	key, _, _ := oldest(b.Value.keys)
+	e := c.items[key]
+	c.unlink(key, b)
+	delete(c.items, key)
+	if c.onEvict != nil {
+		c.onEvict(key, e.value)
+	}
+}
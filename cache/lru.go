@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/danielhookx/xcontainer/list"
+)
+
+// LRU is a cache that evicts the least recently used entry when full.
+// Entries may also be given a time to live, after which they are treated as absent.
+// Every operation runs in O(1).
+type LRU[K comparable, V any] struct {
+	ll      *list.List[*lruEntry[K, V]] // most recently used at the front
+	items   map[K]*list.Element[*lruEntry[K, V]]
+	cap     int
+	ttl     time.Duration
+	onEvict EvictFunc[K, V]
+	now     func() time.Time
+}
+
+type lruEntry[K comparable, V any] struct {
+	key      K
+	value    V
+	expireAt time.Time // zero if the entry never expires
+}
+
+var _ Cache[int, int] = (*LRU[int, int])(nil)
+
+// NewLRU returns an empty LRU cache holding at most capacity entries.
+// It panics if capacity is less than 1.
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	checkCapacity(capacity)
+	return &LRU[K, V]{
+		ll:    list.New[*lruEntry[K, V]](),
+		items: make(map[K]*list.Element[*lruEntry[K, V]]),
+		cap:   capacity,
+		now:   time.Now,
+	}
+}
+
+// OnEvict sets the function called for every evicted or expired entry.
+func (c *LRU[K, V]) OnEvict(fn EvictFunc[K, V]) {
+	c.onEvict = fn
+}
+
+// SetTTL makes entries put from now on expire ttl after they were last put.
+// A ttl of 0 or less disables expiration.
+func (c *LRU[K, V]) SetTTL(ttl time.Duration) {
+	c.ttl = max(ttl, 0)
+}
+
+// Get returns the value stored for key and marks the entry as most recently used.
+// The boolean is false if key is absent or expired.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	e, ok := c.lookup(key)
+	if !ok {
+		return *new(V), false
+	}
+	c.ll.MoveToFront(e)
+	return e.Value.value, true
+}
+
+// Put stores value for key and marks the entry as most recently used.
+// If the cache is full, the least recently used entry is evicted.
+func (c *LRU[K, V]) Put(key K, value V) {
+	var expireAt time.Time
+	if c.ttl > 0 {
+		expireAt = c.now().Add(c.ttl)
+	}
+	if e, ok := c.items[key]; ok {
+		e.Value.value = value
+		e.Value.expireAt = expireAt
+		c.ll.MoveToFront(e)
+		return
+	}
+	if c.ll.Len() >= c.cap {
+		c.evict(c.ll.Back())
+	}
+	c.items[key] = c.ll.PushFront(&lruEntry[K, V]{key: key, value: value, expireAt: expireAt})
+}
+
+// Peek returns the value stored for key without changing its recency.
+// The boolean is false if key is absent or expired.
+func (c *LRU[K, V]) Peek(key K) (V, bool) {
+	e, ok := c.lookup(key)
+	if !ok {
+		return *new(V), false
+	}
+	return e.Value.value, true
+}
+
+// Remove deletes key from the cache and reports whether it was present.
+func (c *LRU[K, V]) Remove(key K) bool {
+	e, ok := c.items[key]
+	if ok {
+		c.ll.Remove(e)
+		delete(c.items, key)
+	}
+	return ok
+}
+
+// Oldest returns the least recently used entry, the next to be evicted.
+// The boolean is false if the cache is empty. Expired entries are not skipped.
+func (c *LRU[K, V]) Oldest() (K, V, bool) {
+	e := c.ll.Back()
+	if e == nil {
+		return *new(K), *new(V), false
+	}
+	return e.Value.key, e.Value.value, true
+}
+
+// Len returns the number of entries in the cache, including expired entries
+// that have not been looked up since they expired.
+func (c *LRU[K, V]) Len() int {
+	return c.ll.Len()
+}
+
+// Cap returns the maximum number of entries in the cache.
+func (c *LRU[K, V]) Cap() int {
+	return c.cap
+}
+
+// Clear removes all entries from the cache.
+func (c *LRU[K, V]) Clear() {
+	c.ll.Init()
+	clear(c.items)
+}
+
+// lookup returns the element of key, evicting it first if it has expired.
+func (c *LRU[K, V]) lookup(key K) (*list.Element[*lruEntry[K, V]], bool) {
+	e, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	if exp := e.Value.expireAt; !exp.IsZero() && !c.now().Before(exp) {
+		c.evict(e)
+		return nil, false
+	}
+	return e, true
+}
+
+func (c *LRU[K, V]) evict(e *list.Element[*lruEntry[K, V]]) {
+	c.ll.Remove(e)
+	delete(c.items, e.Value.key)
+	if c.onEvict != nil {
+		c.onEvict(e.Value.key, e.Value.value)
+	}
+}
@@ -39,7 +39,7 @@ func UndirectedGraphBFS[T comparable](g *UndirectedGraph[T], start T) []T {
 	visited[start] = true
 
 	for q.Len() > 0 {
-		vertex := q.DeQueue()
+		vertex, _ := q.DeQueue()
 		l := g.adj[vertex]
 		for item := range l.Iter() {
 			if v, ok := visited[item]; v && ok {
@@ -1,18 +1,20 @@
 package heap
 
-import "github.com/danielhookx/xcontainer"
+import (
+	"iter"
+
+	"github.com/danielhookx/xcontainer"
+)
 
 type MaxHeap[T xcontainer.Orderliness] struct {
-	nodes    []T
-	last     int
-	maxCount int
+	nodes []T
+	last  int
 }
 
 func HeadBuildHeap[T xcontainer.Orderliness](src []T) *MaxHeap[T] {
 	h := MaxHeap[T]{
-		nodes:    src,
-		last:     len(src) - 1,
-		maxCount: len(src),
+		nodes: src,
+		last:  len(src) - 1,
 	}
 	for i := len(src) / 2; i >= 0; i-- {
 		h.down(i)
@@ -22,9 +24,8 @@ func HeadBuildHeap[T xcontainer.Orderliness](src []T) *MaxHeap[T] {
 
 func TailBuildHeap[T xcontainer.Orderliness](src []T) *MaxHeap[T] {
 	h := MaxHeap[T]{
-		nodes:    make([]T, 0),
-		last:     -1,
-		maxCount: -1,
+		nodes: make([]T, 0),
+		last:  -1,
 	}
 	for _, i := range src {
 		h.Add(i)
@@ -42,8 +43,10 @@ func (h *MaxHeap[T]) Add(item T) {
 	h.up(h.last)
 }
 
+// Pop removes and returns the largest element of the heap.
+// It returns the zero value if the heap is empty; use TryPop to tell the two apart.
 func (h *MaxHeap[T]) Pop() T {
-	if h.last <= 0 {
+	if h.last < 0 {
 		return *new(T)
 	}
 	h.swap(0, h.last)
@@ -52,6 +55,52 @@ func (h *MaxHeap[T]) Pop() T {
 	return h.nodes[h.last+1]
 }
 
+// TryPop removes and returns the largest element of the heap.
+// The boolean is false if the heap is empty.
+func (h *MaxHeap[T]) TryPop() (T, bool) {
+	if h.last < 0 {
+		return *new(T), false
+	}
+	return h.Pop(), true
+}
+
+// Drain returns an iterator that pops the elements of the heap from the largest to the smallest.
+// Elements are removed as they are yielded; breaking out early leaves the rest in the heap.
+func (h *MaxHeap[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for !h.IsEmpty() {
+			if !yield(h.Pop()) {
+				return
+			}
+		}
+	}
+}
+
+// Peek returns the largest element of the heap without removing it.
+// The boolean is false if the heap is empty.
+func (h *MaxHeap[T]) Peek() (T, bool) {
+	if h.last < 0 {
+		return *new(T), false
+	}
+	return h.nodes[0], true
+}
+
+// Len returns the number of elements in the heap.
+func (h *MaxHeap[T]) Len() int {
+	return h.last + 1
+}
+
+// IsEmpty checks whether the heap is empty.
+func (h *MaxHeap[T]) IsEmpty() bool {
+	return h.last < 0
+}
+
+// Clear removes all elements from the heap.
+func (h *MaxHeap[T]) Clear() {
+	clear(h.nodes)
+	h.last = -1
+}
+
 func (h *MaxHeap[T]) up(j int) {
 	for {
 		i := (j - 1) / 2 //parent
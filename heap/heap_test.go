@@ -1,8 +1,12 @@
 package heap
 
 import (
+	"math"
 	"math/rand"
+	"slices"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestHeapSort(t *testing.T) {
@@ -42,3 +46,583 @@ func TestTailBuildHeap(t *testing.T) {
 	}
 	t.Log(h.nodes)
 }
+
+func TestMaxHeapAccessors(t *testing.T) {
+	h := HeadBuildHeap[int]([]int{7, 2, 1, 4, 5, 6, 3})
+	assert.Equal(t, 7, h.Len())
+	assert.False(t, h.IsEmpty())
+	v, ok := h.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, 7, v)
+
+	rlt := make([]int, 0)
+	for !h.IsEmpty() {
+		rlt = append(rlt, h.Pop())
+	}
+	assert.EqualValues(t, []int{7, 6, 5, 4, 3, 2, 1}, rlt)
+	assert.Equal(t, 0, h.Pop())
+	_, ok = h.Peek()
+	assert.False(t, ok)
+
+	h.Add(3)
+	h.Add(9)
+	h.Clear()
+	assert.Equal(t, 0, h.Len())
+	h.Add(1)
+	assert.Equal(t, 1, h.Pop())
+
+	// TryPop tells a stored zero apart from an empty heap
+	h.Add(0)
+	v, ok = h.TryPop()
+	assert.True(t, ok)
+	assert.Equal(t, 0, v)
+	_, ok = h.TryPop()
+	assert.False(t, ok)
+}
+
+func TestMinHeap(t *testing.T) {
+	const count = 100
+	h := NewMinHeap[int]()
+	assert.True(t, h.IsEmpty())
+	_, ok := h.Pop()
+	assert.False(t, ok)
+	_, ok = h.Peek()
+	assert.False(t, ok)
+
+	for i := 0; i < count; i++ {
+		h.Push(rand.Intn(count))
+	}
+	assert.Equal(t, count, h.Len())
+	top, _ := h.Peek()
+	preV, _ := h.Pop()
+	assert.Equal(t, top, preV)
+	for !h.IsEmpty() {
+		v, ok := h.Pop()
+		assert.True(t, ok)
+		if v < preV {
+			t.Error("not minHeap")
+		}
+		preV = v
+	}
+
+	sh := BuildMinHeap([]string{"pear", "apple", "fig", "banana"})
+	rlt := make([]string, 0)
+	for sh.Len() > 0 {
+		v, _ := sh.Pop()
+		rlt = append(rlt, v)
+	}
+	assert.EqualValues(t, []string{"apple", "banana", "fig", "pear"}, rlt)
+}
+
+func TestHeapLess(t *testing.T) {
+	type job struct {
+		deadline int
+		id       string
+	}
+	less := func(a, b job) bool {
+		if a.deadline != b.deadline {
+			return a.deadline < b.deadline
+		}
+		return a.id < b.id
+	}
+	h := BuildHeap([]job{{3, "c"}, {1, "b"}, {3, "a"}}, less)
+	h.Push(job{2, "d"})
+	h.Push(job{1, "a"})
+	rlt := make([]string, 0)
+	for {
+		j, ok := h.Pop()
+		if !ok {
+			break
+		}
+		rlt = append(rlt, j.id)
+	}
+	assert.EqualValues(t, []string{"a", "b", "d", "a", "c"}, rlt)
+
+	h.Push(job{5, "e"})
+	h.Clear()
+	assert.True(t, h.IsEmpty())
+	assert.Equal(t, 0, h.Len())
+}
+
+func TestIndexedHeap(t *testing.T) {
+	h := NewIndexedHeap[string, int](func(a, b int) bool { return a < b })
+	assert.True(t, h.IsEmpty())
+	_, _, ok := h.Pop()
+	assert.False(t, ok)
+
+	assert.True(t, h.Push("a", 5))
+	assert.True(t, h.Push("b", 3))
+	assert.True(t, h.Push("c", 8))
+	assert.True(t, h.Push("d", 1))
+	assert.False(t, h.Push("a", 0))
+	assert.Equal(t, 4, h.Len())
+	assert.True(t, h.Contains("c"))
+	assert.False(t, h.Contains("z"))
+
+	k, p, ok := h.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, "d", k)
+	assert.Equal(t, 1, p)
+
+	// decrease key
+	assert.True(t, h.Update("c", 0))
+	k, _, _ = h.Peek()
+	assert.Equal(t, "c", k)
+	// increase key
+	assert.True(t, h.Update("c", 10))
+	assert.False(t, h.Update("z", 1))
+	p, ok = h.Priority("c")
+	assert.True(t, ok)
+	assert.Equal(t, 10, p)
+
+	p, ok = h.Remove("b")
+	assert.True(t, ok)
+	assert.Equal(t, 3, p)
+	_, ok = h.Remove("b")
+	assert.False(t, ok)
+	assert.False(t, h.Contains("b"))
+
+	rlt := make([]string, 0)
+	for !h.IsEmpty() {
+		k, _, _ := h.Pop()
+		rlt = append(rlt, k)
+	}
+	assert.EqualValues(t, []string{"d", "a", "c"}, rlt)
+
+	h.Push("x", 1)
+	h.Clear()
+	assert.Equal(t, 0, h.Len())
+	assert.False(t, h.Contains("x"))
+	assert.True(t, h.Push("x", 2))
+}
+
+func TestIndexedHeapRandom(t *testing.T) {
+	h := NewIndexedHeap[int, int](func(a, b int) bool { return a < b })
+	want := make(map[int]int)
+	for i := 0; i < 5000; i++ {
+		key := rand.Intn(200)
+		switch rand.Intn(4) {
+		case 0:
+			_, ok := h.Remove(key)
+			_, exist := want[key]
+			assert.Equal(t, exist, ok)
+			delete(want, key)
+		case 1:
+			p := rand.Intn(1000)
+			_, exist := want[key]
+			assert.Equal(t, exist, h.Update(key, p))
+			if exist {
+				want[key] = p
+			}
+		default:
+			p := rand.Intn(1000)
+			_, exist := want[key]
+			assert.Equal(t, !exist, h.Push(key, p))
+			if !exist {
+				want[key] = p
+			}
+		}
+	}
+	assert.Equal(t, len(want), h.Len())
+	pre := -1
+	for !h.IsEmpty() {
+		k, p, _ := h.Pop()
+		assert.Equal(t, want[k], p)
+		if p < pre {
+			t.Error("not minHeap")
+		}
+		pre = p
+	}
+}
+
+// TestIndexedHeapDijkstra runs Dijkstra's shortest paths using Update as decrease-key.
+func TestIndexedHeapDijkstra(t *testing.T) {
+	type edge struct {
+		to, w int
+	}
+	g := map[int][]edge{
+		0: {{1, 4}, {2, 1}},
+		2: {{1, 2}, {3, 5}},
+		1: {{3, 1}},
+		3: {{4, 3}},
+	}
+	dist := map[int]int{0: 0}
+	h := NewIndexedHeap[int, int](func(a, b int) bool { return a < b })
+	h.Push(0, 0)
+	for !h.IsEmpty() {
+		u, d, _ := h.Pop()
+		for _, e := range g[u] {
+			nd := d + e.w
+			if old, ok := dist[e.to]; ok && old <= nd {
+				continue
+			}
+			dist[e.to] = nd
+			if !h.Update(e.to, nd) {
+				h.Push(e.to, nd)
+			}
+		}
+	}
+	assert.Equal(t, map[int]int{0: 0, 1: 3, 2: 1, 3: 4, 4: 7}, dist)
+}
+
+func TestTopK(t *testing.T) {
+	tk := NewTopK(3, func(a, b int) bool { return a < b })
+	assert.Equal(t, 3, tk.Cap())
+	_, ok := tk.Peek()
+	assert.False(t, ok)
+	for _, v := range []int{5, 1, 9, 3, 7} {
+		tk.Push(v)
+	}
+	assert.Equal(t, 3, tk.Len())
+	v, ok := tk.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, 5, v)
+	assert.False(t, tk.Push(4))
+	assert.True(t, tk.Push(8))
+	assert.EqualValues(t, []int{9, 8, 7}, tk.Sorted())
+	assert.EqualValues(t, []int{9, 8, 7}, tk.Sorted())
+
+	tk.Clear()
+	assert.Equal(t, 0, tk.Len())
+	assert.True(t, tk.Push(1))
+
+	none := NewTopK(0, func(a, b int) bool { return a < b })
+	assert.False(t, none.Push(1))
+	assert.Empty(t, none.Sorted())
+}
+
+func TestTopKHotKeys(t *testing.T) {
+	type hit struct {
+		key   string
+		count int
+	}
+	hits := []hit{{"a", 10}, {"b", 50}, {"c", 5}, {"d", 70}, {"e", 20}}
+	top := TopKFromFunc(slices.Values(hits), 2, func(x, y hit) bool { return x.count < y.count })
+	assert.EqualValues(t, []hit{{"d", 70}, {"b", 50}}, top)
+}
+
+func TestTopKFrom(t *testing.T) {
+	src := rand.Perm(1000)
+	assert.EqualValues(t, []int{999, 998, 997, 996, 995}, TopKFrom(slices.Values(src), 5))
+	assert.EqualValues(t, []int{0, 1, 2, 3, 4}, BottomKFrom(slices.Values(src), 5))
+	assert.EqualValues(t, []string{"c", "b", "a"}, TopKFrom(slices.Values([]string{"b", "a", "c"}), 10))
+	assert.Empty(t, TopKFrom(slices.Values(src), 0))
+	// k far beyond the input keeps everything without reserving room for k elements
+	assert.EqualValues(t, []int{3, 2, 1}, TopKFrom(slices.Values([]int{1, 2, 3}), math.MaxInt))
+	assert.EqualValues(t, []int{1, 2, 3}, BottomKFrom(slices.Values([]int{3, 1, 2}), 1e8))
+}
+
+func TestHeapSortSlice(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 7, 100} {
+		src := make([]int, n)
+		for i := range src {
+			src[i] = rand.Intn(50)
+		}
+		want := slices.Clone(src)
+		slices.Sort(want)
+		HeapSort(src)
+		assert.EqualValues(t, want, src)
+	}
+	s := []string{"pear", "apple", "fig", "banana"}
+	HeapSort(s)
+	assert.EqualValues(t, []string{"apple", "banana", "fig", "pear"}, s)
+}
+
+func TestDrain(t *testing.T) {
+	h := HeadBuildHeap([]int{7, 2, 1, 4, 5, 6, 3})
+	rlt := make([]int, 0)
+	for v := range h.Drain() {
+		if v < 4 {
+			break
+		}
+		rlt = append(rlt, v)
+	}
+	assert.EqualValues(t, []int{7, 6, 5, 4}, rlt)
+	assert.Equal(t, 2, h.Len())
+	assert.EqualValues(t, []int{2, 1}, slices.Collect(h.Drain()))
+	assert.True(t, h.IsEmpty())
+
+	mh := BuildMinHeap([]int{7, 2, 1, 4})
+	assert.EqualValues(t, []int{1, 2, 4, 7}, slices.Collect(mh.Drain()))
+	assert.True(t, mh.IsEmpty())
+}
+
+func TestMergeSorted(t *testing.T) {
+	merged := MergeSorted(
+		slices.Values([]int{1, 4, 9}),
+		slices.Values([]int{}),
+		slices.Values([]int{2, 3, 10, 11}),
+		slices.Values([]int{0, 4, 5}),
+	)
+	assert.EqualValues(t, []int{0, 1, 2, 3, 4, 4, 5, 9, 10, 11}, slices.Collect(merged))
+	assert.Empty(t, slices.Collect(MergeSorted[int]()))
+
+	// stop early and release the pulled sequences
+	released := 0
+	seq := func(vals ...int) func(func(int) bool) {
+		return func(yield func(int) bool) {
+			defer func() { released++ }()
+			for _, v := range vals {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+	rlt := make([]int, 0)
+	for v := range MergeSorted(seq(1, 3, 5), seq(2, 4, 6)) {
+		if v > 3 {
+			break
+		}
+		rlt = append(rlt, v)
+	}
+	assert.EqualValues(t, []int{1, 2, 3}, rlt)
+	assert.Equal(t, 2, released)
+}
+
+func TestPairingHeap(t *testing.T) {
+	var h Interface[int] = NewPairingHeap(func(a, b int) bool { return a < b })
+	_, ok := h.Pop()
+	assert.False(t, ok)
+	for _, v := range []int{5, 1, 9, 3, 7, 3} {
+		h.Push(v)
+	}
+	assert.Equal(t, 6, h.Len())
+	v, ok := h.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	rlt := make([]int, 0)
+	for !h.IsEmpty() {
+		v, _ := h.Pop()
+		rlt = append(rlt, v)
+	}
+	assert.EqualValues(t, []int{1, 3, 3, 5, 7, 9}, rlt)
+	h.Push(1)
+	h.Clear()
+	assert.True(t, h.IsEmpty())
+}
+
+func TestPairingHeapMeldDecreaseKey(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	a, b := NewPairingHeap(less), NewPairingHeap(less)
+	nodes := make(map[int]*PairingNode[int])
+	for _, v := range []int{10, 20, 30} {
+		nodes[v] = a.Insert(v)
+	}
+	for _, v := range []int{15, 25, 35} {
+		nodes[v] = b.Insert(v)
+	}
+	a.Meld(b)
+	assert.Equal(t, 6, a.Len())
+	assert.True(t, b.IsEmpty())
+	a.Meld(a)
+	assert.Equal(t, 6, a.Len())
+
+	a.DecreaseKey(nodes[35], 5)
+	v, _ := a.Peek()
+	assert.Equal(t, 5, v)
+	// increasing through DecreaseKey still keeps the heap valid
+	a.DecreaseKey(nodes[10], 40)
+	assert.Equal(t, 30, a.Remove(nodes[30]))
+	assert.Equal(t, 5, a.Remove(nodes[35]))
+	assert.Equal(t, 4, a.Len())
+	assert.EqualValues(t, []int{15, 20, 25, 40}, drainPairing(a))
+}
+
+func drainPairing(h *PairingHeap[int]) []int {
+	rlt := make([]int, 0, h.Len())
+	for !h.IsEmpty() {
+		v, _ := h.Pop()
+		rlt = append(rlt, v)
+	}
+	return rlt
+}
+
+func TestPairingHeapRandom(t *testing.T) {
+	h := NewPairingHeap(func(a, b int) bool { return a < b })
+	live := make([]*PairingNode[int], 0)
+	for i := 0; i < 5000; i++ {
+		switch op := rand.Intn(5); {
+		case op == 0 && len(live) > 0:
+			j := rand.Intn(len(live))
+			h.Remove(live[j])
+			live = append(live[:j], live[j+1:]...)
+		case op == 1 && len(live) > 0:
+			h.DecreaseKey(live[rand.Intn(len(live))], rand.Intn(1000))
+		default:
+			live = append(live, h.Insert(rand.Intn(1000)))
+		}
+	}
+	want := make([]int, 0, len(live))
+	for _, n := range live {
+		want = append(want, n.Value)
+	}
+	slices.Sort(want)
+	assert.Equal(t, len(want), h.Len())
+	assert.EqualValues(t, want, drainPairing(h))
+}
+
+const benchmarkHeapSize = 10000
+
+func BenchmarkMaxHeapPushPop(b *testing.B) {
+	src := rand.Perm(benchmarkHeapSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h := TailBuildHeap[int](nil)
+		for _, v := range src {
+			h.Add(v)
+		}
+		for !h.IsEmpty() {
+			h.Pop()
+		}
+	}
+}
+
+func BenchmarkPairingHeapPushPop(b *testing.B) {
+	src := rand.Perm(benchmarkHeapSize)
+	greater := func(a, b int) bool { return a > b }
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h := NewPairingHeap(greater)
+		for _, v := range src {
+			h.Push(v)
+		}
+		for !h.IsEmpty() {
+			h.Pop()
+		}
+	}
+}
+
+func BenchmarkMaxHeapMeld(b *testing.B) {
+	src := rand.Perm(benchmarkHeapSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x, y := TailBuildHeap(src[:benchmarkHeapSize/2]), TailBuildHeap(src[benchmarkHeapSize/2:])
+		for !y.IsEmpty() {
+			x.Add(y.Pop())
+		}
+	}
+}
+
+func BenchmarkPairingHeapMeld(b *testing.B) {
+	src := rand.Perm(benchmarkHeapSize)
+	greater := func(a, b int) bool { return a > b }
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x, y := NewPairingHeap(greater), NewPairingHeap(greater)
+		for _, v := range src[:benchmarkHeapSize/2] {
+			x.Push(v)
+		}
+		for _, v := range src[benchmarkHeapSize/2:] {
+			y.Push(v)
+		}
+		x.Meld(y)
+	}
+}
+
+func BenchmarkIndexedHeapUpdate(b *testing.B) {
+	h := NewIndexedHeap[int, int](func(a, b int) bool { return a < b })
+	for i := 0; i < benchmarkHeapSize; i++ {
+		h.Push(i, benchmarkHeapSize+i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		k := i % benchmarkHeapSize
+		p, _ := h.Priority(k)
+		h.Update(k, p-1)
+	}
+}
+
+func BenchmarkPairingHeapDecreaseKey(b *testing.B) {
+	h := NewPairingHeap(func(a, b int) bool { return a < b })
+	nodes := make([]*PairingNode[int], benchmarkHeapSize)
+	for i := range nodes {
+		nodes[i] = h.Insert(benchmarkHeapSize + i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		n := nodes[i%benchmarkHeapSize]
+		h.DecreaseKey(n, n.Value-1)
+	}
+}
+
+func TestMinMaxHeap(t *testing.T) {
+	h := NewOrderedMinMaxHeap[int]()
+	_, ok := h.PeekMin()
+	assert.False(t, ok)
+	_, ok = h.PopMax()
+	assert.False(t, ok)
+
+	h.Push(5)
+	v, _ := h.PeekMax()
+	assert.Equal(t, 5, v)
+	for _, v := range []int{1, 9, 3, 7, 3} {
+		h.Push(v)
+	}
+	assert.Equal(t, 6, h.Len())
+	v, _ = h.PeekMin()
+	assert.Equal(t, 1, v)
+	v, _ = h.PeekMax()
+	assert.Equal(t, 9, v)
+
+	v, _ = h.PopMax()
+	assert.Equal(t, 9, v)
+	v, _ = h.PopMin()
+	assert.Equal(t, 1, v)
+	v, _ = h.PopMax()
+	assert.Equal(t, 7, v)
+	v, _ = h.PopMin()
+	assert.Equal(t, 3, v)
+	assert.Equal(t, 2, h.Len())
+
+	h.Clear()
+	assert.True(t, h.IsEmpty())
+
+	type req struct {
+		cost int
+		id   string
+	}
+	rh := NewMinMaxHeap(func(a, b req) bool { return a.cost < b.cost })
+	rh.Push(req{30, "a"})
+	rh.Push(req{10, "b"})
+	rh.Push(req{20, "c"})
+	r, _ := rh.PopMax()
+	assert.Equal(t, "a", r.id)
+	r, _ = rh.PopMin()
+	assert.Equal(t, "b", r.id)
+}
+
+func TestMinMaxHeapRandom(t *testing.T) {
+	h := NewOrderedMinMaxHeap[int]()
+	want := make([]int, 0)
+	for i := 0; i < 5000; i++ {
+		switch rand.Intn(4) {
+		case 0:
+			v, ok := h.PopMin()
+			assert.Equal(t, len(want) > 0, ok)
+			if ok {
+				assert.Equal(t, want[0], v)
+				want = want[1:]
+			}
+		case 1:
+			v, ok := h.PopMax()
+			assert.Equal(t, len(want) > 0, ok)
+			if ok {
+				assert.Equal(t, want[len(want)-1], v)
+				want = want[:len(want)-1]
+			}
+		default:
+			v := rand.Intn(1000)
+			h.Push(v)
+			i, _ := slices.BinarySearch(want, v)
+			want = slices.Insert(want, i, v)
+		}
+		assert.Equal(t, len(want), h.Len())
+		if len(want) > 0 {
+			lo, _ := h.PeekMin()
+			hi, _ := h.PeekMax()
+			assert.Equal(t, want[0], lo)
+			assert.Equal(t, want[len(want)-1], hi)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package heap
+
+// IndexedHeap is a binary heap of distinct keys ordered by their priorities.
+// It keeps the position of every key, so the priority of a queued key can be
+// changed and any key can be removed in O(log n), as needed by Dijkstra-style algorithms.
+type IndexedHeap[K comparable, P any] struct {
+	nodes []indexedNode[K, P]
+	index map[K]int // position of each key in nodes
+	less  func(a, b P) bool
+}
+
+type indexedNode[K comparable, P any] struct {
+	key      K
+	priority P
+}
+
+// NewIndexedHeap returns an empty IndexedHeap that pops the key with the smallest priority according to less first.
+func NewIndexedHeap[K comparable, P any](less func(a, b P) bool) *IndexedHeap[K, P] {
+	return &IndexedHeap[K, P]{
+		nodes: make([]indexedNode[K, P], 0),
+		index: make(map[K]int),
+		less:  less,
+	}
+}
+
+// Push adds key with the given priority.
+// Returns false, leaving the heap unchanged, if key is already queued.
+// The complexity is O(log n).
+func (h *IndexedHeap[K, P]) Push(key K, priority P) bool {
+	if _, ok := h.index[key]; ok {
+		return false
+	}
+	h.nodes = append(h.nodes, indexedNode[K, P]{key: key, priority: priority})
+	h.index[key] = len(h.nodes) - 1
+	h.up(len(h.nodes) - 1)
+	return true
+}
+
+// Update changes the priority of a queued key, moving it up or down as needed.
+// Returns false if key is not queued.
+// The complexity is O(log n).
+func (h *IndexedHeap[K, P]) Update(key K, priority P) bool {
+	i, ok := h.index[key]
+	if !ok {
+		return false
+	}
+	h.nodes[i].priority = priority
+	h.fix(i)
+	return true
+}
+
+// Remove removes key from the heap and returns its priority.
+// The boolean is false if key is not queued.
+// The complexity is O(log n).
+func (h *IndexedHeap[K, P]) Remove(key K) (P, bool) {
+	i, ok := h.index[key]
+	if !ok {
+		return *new(P), false
+	}
+	return h.removeAt(i).priority, true
+}
+
+// Pop removes and returns the key with the first priority.
+// The boolean is false if the heap is empty.
+// The complexity is O(log n).
+func (h *IndexedHeap[K, P]) Pop() (K, P, bool) {
+	if len(h.nodes) == 0 {
+		return *new(K), *new(P), false
+	}
+	n := h.removeAt(0)
+	return n.key, n.priority, true
+}
+
+// Peek returns the key with the first priority without removing it.
+// The boolean is false if the heap is empty.
+func (h *IndexedHeap[K, P]) Peek() (K, P, bool) {
+	if len(h.nodes) == 0 {
+		return *new(K), *new(P), false
+	}
+	return h.nodes[0].key, h.nodes[0].priority, true
+}
+
+// Contains checks whether key is queued.
+func (h *IndexedHeap[K, P]) Contains(key K) bool {
+	_, ok := h.index[key]
+	return ok
+}
+
+// Priority returns the priority of a queued key.
+// The boolean is false if key is not queued.
+func (h *IndexedHeap[K, P]) Priority(key K) (P, bool) {
+	i, ok := h.index[key]
+	if !ok {
+		return *new(P), false
+	}
+	return h.nodes[i].priority, true
+}
+
+// Len returns the number of keys in the heap.
+func (h *IndexedHeap[K, P]) Len() int {
+	return len(h.nodes)
+}
+
+// IsEmpty checks whether the heap is empty.
+func (h *IndexedHeap[K, P]) IsEmpty() bool {
+	return len(h.nodes) == 0
+}
+
+// Clear removes all keys from the heap.
+func (h *IndexedHeap[K, P]) Clear() {
+	clear(h.nodes)
+	h.nodes = h.nodes[:0]
+	clear(h.index)
+}
+
+// removeAt removes the node at position i and restores the heap order.
+func (h *IndexedHeap[K, P]) removeAt(i int) indexedNode[K, P] {
+	last := len(h.nodes) - 1
+	h.swap(i, last)
+	n := h.nodes[last]
+	h.nodes[last] = indexedNode[K, P]{} // avoid memory leaks
+	h.nodes = h.nodes[:last]
+	delete(h.index, n.key)
+	if i < last {
+		h.fix(i)
+	}
+	return n
+}
+
+// fix restores the heap order after the priority at position i changed.
+func (h *IndexedHeap[K, P]) fix(i int) {
+	if !h.down(i) {
+		h.up(i)
+	}
+}
+
+func (h *IndexedHeap[K, P]) up(j int) {
+	for j > 0 {
+		i := (j - 1) / 2 //parent
+		if !h.less(h.nodes[j].priority, h.nodes[i].priority) {
+			break
+		}
+		h.swap(i, j)
+		j = i
+	}
+}
+
+// down moves the node at position i towards the leaves and reports whether it moved.
+func (h *IndexedHeap[K, P]) down(i0 int) bool {
+	i, n := i0, len(h.nodes)
+	for {
+		//left
+		j := 2*i + 1
+		if j >= n || j < 0 {
+			break
+		}
+		if j2 := j + 1; j2 < n && h.less(h.nodes[j2].priority, h.nodes[j].priority) {
+			j = j2
+		}
+		if !h.less(h.nodes[j].priority, h.nodes[i].priority) {
+			break
+		}
+		h.swap(i, j)
+		i = j
+	}
+	return i > i0
+}
+
+func (h *IndexedHeap[K, P]) swap(i, j int) {
+	h.nodes[i], h.nodes[j] = h.nodes[j], h.nodes[i]
+	h.index[h.nodes[i].key] = i
+	h.index[h.nodes[j].key] = j
+}
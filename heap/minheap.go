@@ -0,0 +1,150 @@
+package heap
+
+import (
+	"iter"
+
+	"github.com/danielhookx/xcontainer"
+)
+
+// Heap is a binary heap ordered by a user-supplied less function:
+// Pop always returns an element x such that less(y, x) is false for every other element y.
+type Heap[T any] struct {
+	nodes []T
+	less  func(a, b T) bool
+}
+
+// NewHeap returns an empty heap ordered by less.
+func NewHeap[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{
+		nodes: make([]T, 0),
+		less:  less,
+	}
+}
+
+// BuildHeap returns a heap ordered by less holding the elements of src in O(n).
+// The heap takes ownership of src.
+func BuildHeap[T any](src []T, less func(a, b T) bool) *Heap[T] {
+	h := &Heap[T]{
+		nodes: src,
+		less:  less,
+	}
+	for i := len(src)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h
+}
+
+// Push adds item to the heap.
+// The complexity is O(log n).
+func (h *Heap[T]) Push(item T) {
+	h.nodes = append(h.nodes, item)
+	h.up(len(h.nodes) - 1)
+}
+
+// Pop removes and returns the first element of the heap.
+// The boolean is false if the heap is empty.
+// The complexity is O(log n).
+func (h *Heap[T]) Pop() (T, bool) {
+	if len(h.nodes) == 0 {
+		return *new(T), false
+	}
+	last := len(h.nodes) - 1
+	h.swap(0, last)
+	v := h.nodes[last]
+	h.nodes[last] = *new(T) // avoid memory leaks
+	h.nodes = h.nodes[:last]
+	h.down(0)
+	return v, true
+}
+
+// Drain returns an iterator that pops the elements of the heap in priority order.
+// Elements are removed as they are yielded; breaking out early leaves the rest in the heap.
+func (h *Heap[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for len(h.nodes) > 0 {
+			v, _ := h.Pop()
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Peek returns the first element of the heap without removing it.
+// The boolean is false if the heap is empty.
+func (h *Heap[T]) Peek() (T, bool) {
+	if len(h.nodes) == 0 {
+		return *new(T), false
+	}
+	return h.nodes[0], true
+}
+
+// Len returns the number of elements in the heap.
+func (h *Heap[T]) Len() int {
+	return len(h.nodes)
+}
+
+// IsEmpty checks whether the heap is empty.
+func (h *Heap[T]) IsEmpty() bool {
+	return len(h.nodes) == 0
+}
+
+// Clear removes all elements from the heap.
+func (h *Heap[T]) Clear() {
+	clear(h.nodes)
+	h.nodes = h.nodes[:0]
+}
+
+func (h *Heap[T]) up(j int) {
+	for j > 0 {
+		i := (j - 1) / 2 //parent
+		if !h.less(h.nodes[j], h.nodes[i]) {
+			break
+		}
+		h.swap(i, j)
+		j = i
+	}
+}
+
+func (h *Heap[T]) down(i int) {
+	n := len(h.nodes)
+	for {
+		//left
+		j := 2*i + 1
+		if j >= n || j < 0 {
+			break
+		}
+		if j2 := j + 1; j2 < n && h.less(h.nodes[j2], h.nodes[j]) {
+			j = j2
+		}
+		if !h.less(h.nodes[j], h.nodes[i]) {
+			break
+		}
+		h.swap(i, j)
+		i = j
+	}
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	h.nodes[i], h.nodes[j] = h.nodes[j], h.nodes[i]
+}
+
+// MinHeap is a heap that pops its smallest element first.
+type MinHeap[T xcontainer.Orderliness] struct {
+	Heap[T]
+}
+
+// NewMinHeap returns an empty MinHeap.
+func NewMinHeap[T xcontainer.Orderliness]() *MinHeap[T] {
+	return &MinHeap[T]{Heap: *NewHeap(lessOf[T])}
+}
+
+// BuildMinHeap returns a MinHeap holding the elements of src in O(n).
+// The heap takes ownership of src.
+func BuildMinHeap[T xcontainer.Orderliness](src []T) *MinHeap[T] {
+	return &MinHeap[T]{Heap: *BuildHeap(src, lessOf[T])}
+}
+
+func lessOf[T xcontainer.Orderliness](a, b T) bool {
+	return a < b
+}
@@ -0,0 +1,188 @@
+package heap
+
+import (
+	"math/bits"
+
+	"github.com/danielhookx/xcontainer"
+)
+
+// MinMaxHeap is a double-ended priority queue ordered by a user-supplied less function.
+// Nodes on even levels are no larger than their descendants and nodes on odd levels
+// no smaller, so both the smallest and the largest element are found in O(1) and
+// removed in O(log n).
+type MinMaxHeap[T any] struct {
+	nodes []T
+	less  func(a, b T) bool
+}
+
+// NewMinMaxHeap returns an empty MinMaxHeap ordered by less.
+func NewMinMaxHeap[T any](less func(a, b T) bool) *MinMaxHeap[T] {
+	return &MinMaxHeap[T]{
+		nodes: make([]T, 0),
+		less:  less,
+	}
+}
+
+// NewOrderedMinMaxHeap returns an empty MinMaxHeap ordered by <.
+func NewOrderedMinMaxHeap[T xcontainer.Orderliness]() *MinMaxHeap[T] {
+	return NewMinMaxHeap(lessOf[T])
+}
+
+// Push adds item to the heap.
+// The complexity is O(log n).
+func (h *MinMaxHeap[T]) Push(item T) {
+	h.nodes = append(h.nodes, item)
+	h.up(len(h.nodes) - 1)
+}
+
+// PeekMin returns the smallest element of the heap without removing it.
+// The boolean is false if the heap is empty.
+func (h *MinMaxHeap[T]) PeekMin() (T, bool) {
+	if len(h.nodes) == 0 {
+		return *new(T), false
+	}
+	return h.nodes[0], true
+}
+
+// PeekMax returns the largest element of the heap without removing it.
+// The boolean is false if the heap is empty.
+func (h *MinMaxHeap[T]) PeekMax() (T, bool) {
+	if len(h.nodes) == 0 {
+		return *new(T), false
+	}
+	return h.nodes[h.maxIndex()], true
+}
+
+// PopMin removes and returns the smallest element of the heap.
+// The boolean is false if the heap is empty.
+// The complexity is O(log n).
+func (h *MinMaxHeap[T]) PopMin() (T, bool) {
+	if len(h.nodes) == 0 {
+		return *new(T), false
+	}
+	return h.removeAt(0), true
+}
+
+// PopMax removes and returns the largest element of the heap.
+// The boolean is false if the heap is empty.
+// The complexity is O(log n).
+func (h *MinMaxHeap[T]) PopMax() (T, bool) {
+	if len(h.nodes) == 0 {
+		return *new(T), false
+	}
+	return h.removeAt(h.maxIndex()), true
+}
+
+// Len returns the number of elements in the heap.
+func (h *MinMaxHeap[T]) Len() int {
+	return len(h.nodes)
+}
+
+// IsEmpty checks whether the heap is empty.
+func (h *MinMaxHeap[T]) IsEmpty() bool {
+	return len(h.nodes) == 0
+}
+
+// Clear removes all elements from the heap.
+func (h *MinMaxHeap[T]) Clear() {
+	clear(h.nodes)
+	h.nodes = h.nodes[:0]
+}
+
+// maxIndex returns the position of the largest element of a non-empty heap.
+func (h *MinMaxHeap[T]) maxIndex() int {
+	switch len(h.nodes) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if h.less(h.nodes[1], h.nodes[2]) {
+		return 2
+	}
+	return 1
+}
+
+func (h *MinMaxHeap[T]) removeAt(i int) T {
+	last := len(h.nodes) - 1
+	h.swap(i, last)
+	v := h.nodes[last]
+	h.nodes[last] = *new(T) // avoid memory leaks
+	h.nodes = h.nodes[:last]
+	if i < last {
+		h.down(i)
+	}
+	return v
+}
+
+// isMinLevel reports whether position i lies on an even (min) level.
+func isMinLevel(i int) bool {
+	return bits.Len(uint(i+1))%2 == 1
+}
+
+// ordered returns less for nodes on min levels and its reverse for nodes on max levels.
+func (h *MinMaxHeap[T]) ordered(minLevel bool) func(a, b T) bool {
+	if minLevel {
+		return h.less
+	}
+	return func(a, b T) bool { return h.less(b, a) }
+}
+
+func (h *MinMaxHeap[T]) up(i int) {
+	if i == 0 {
+		return
+	}
+	minLevel := isMinLevel(i)
+	p := (i - 1) / 2 //parent
+	if h.ordered(!minLevel)(h.nodes[i], h.nodes[p]) {
+		// i belongs on the other kind of level
+		h.swap(i, p)
+		h.upLevel(p, !minLevel)
+		return
+	}
+	h.upLevel(i, minLevel)
+}
+
+// upLevel moves the node at position i up through the grandparents on its own kind of level.
+func (h *MinMaxHeap[T]) upLevel(i int, minLevel bool) {
+	before := h.ordered(minLevel)
+	for i > 2 {
+		g := ((i-1)/2 - 1) / 2 //grandparent
+		if !before(h.nodes[i], h.nodes[g]) {
+			break
+		}
+		h.swap(i, g)
+		i = g
+	}
+}
+
+func (h *MinMaxHeap[T]) down(i int) {
+	before := h.ordered(isMinLevel(i))
+	n := len(h.nodes)
+	for {
+		// m is the first of the children and grandchildren of i
+		m := -1
+		for _, j := range [...]int{2*i + 1, 2*i + 2, 4*i + 3, 4*i + 4, 4*i + 5, 4*i + 6} {
+			if j < n && (m < 0 || before(h.nodes[j], h.nodes[m])) {
+				m = j
+			}
+		}
+		if m < 0 || !before(h.nodes[m], h.nodes[i]) {
+			return
+		}
+		h.swap(i, m)
+		if m <= 2*i+2 {
+			// a child only comes first if none of its own children rank before it,
+			// so the swapped node already fits on the child's level
+			return
+		}
+		if p := (m - 1) / 2; before(h.nodes[p], h.nodes[m]) {
+			h.swap(m, p)
+		}
+		i = m
+	}
+}
+
+func (h *MinMaxHeap[T]) swap(i, j int) {
+	h.nodes[i], h.nodes[j] = h.nodes[j], h.nodes[i]
+}
@@ -0,0 +1,204 @@
+package heap
+
+// Interface is the set of operations shared by the heaps ordered by a less function,
+// so callers can switch between the array backed Heap and the mergeable PairingHeap.
+type Interface[T any] interface {
+	Push(item T)
+	Pop() (T, bool)
+	Peek() (T, bool)
+	Len() int
+	IsEmpty() bool
+	Clear()
+}
+
+var (
+	_ Interface[int] = (*Heap[int])(nil)
+	_ Interface[int] = (*PairingHeap[int])(nil)
+)
+
+// PairingNode is an element of a PairingHeap.
+// It is returned by Insert and serves as a handle for DecreaseKey and Remove.
+type PairingNode[T any] struct {
+	// Value is the element held by the node.
+	// It must only be changed through DecreaseKey.
+	Value T
+
+	child   *PairingNode[T] // leftmost child
+	sibling *PairingNode[T] // next sibling to the right
+	prev    *PairingNode[T] // parent if leftmost child, left sibling otherwise
+}
+
+// PairingHeap is a heap-ordered multiway tree ordered by a user-supplied less function.
+// Push and Meld run in O(1), DecreaseKey in o(log n) amortized and Pop in O(log n) amortized,
+// which suits workloads that merge whole queues or change priorities often.
+type PairingHeap[T any] struct {
+	root *PairingNode[T]
+	len  int
+	less func(a, b T) bool
+}
+
+// NewPairingHeap returns an empty PairingHeap that pops the smallest element according to less first.
+func NewPairingHeap[T any](less func(a, b T) bool) *PairingHeap[T] {
+	return &PairingHeap[T]{less: less}
+}
+
+// Insert adds item to the heap and returns its node.
+// The complexity is O(1).
+func (h *PairingHeap[T]) Insert(item T) *PairingNode[T] {
+	n := &PairingNode[T]{Value: item}
+	h.root = h.link(h.root, n)
+	h.len++
+	return n
+}
+
+// Push adds item to the heap.
+// The complexity is O(1).
+func (h *PairingHeap[T]) Push(item T) {
+	h.Insert(item)
+}
+
+// Pop removes and returns the first element of the heap.
+// The boolean is false if the heap is empty.
+// The complexity is O(log n) amortized.
+func (h *PairingHeap[T]) Pop() (T, bool) {
+	if h.root == nil {
+		return *new(T), false
+	}
+	n := h.root
+	h.root = h.mergePairs(n.child)
+	h.len--
+	n.child = nil
+	return n.Value, true
+}
+
+// Peek returns the first element of the heap without removing it.
+// The boolean is false if the heap is empty.
+func (h *PairingHeap[T]) Peek() (T, bool) {
+	if h.root == nil {
+		return *new(T), false
+	}
+	return h.root.Value, true
+}
+
+// Len returns the number of elements in the heap.
+func (h *PairingHeap[T]) Len() int {
+	return h.len
+}
+
+// IsEmpty checks whether the heap is empty.
+func (h *PairingHeap[T]) IsEmpty() bool {
+	return h.len == 0
+}
+
+// Clear removes all elements from the heap.
+func (h *PairingHeap[T]) Clear() {
+	h.root = nil
+	h.len = 0
+}
+
+// Meld moves all elements of other into h, leaving other empty.
+// Nodes of other remain valid handles, now belonging to h.
+// The complexity is O(1).
+func (h *PairingHeap[T]) Meld(other *PairingHeap[T]) {
+	if other == h {
+		return
+	}
+	h.root = h.link(h.root, other.root)
+	h.len += other.len
+	other.Clear()
+}
+
+// DecreaseKey replaces the value of node n, which must belong to h, with item.
+// If item does not rank after the current value, the subtree of n is cut and relinked at the root;
+// otherwise n is removed and reinserted in O(log n) amortized.
+func (h *PairingHeap[T]) DecreaseKey(n *PairingNode[T], item T) {
+	if h.less(n.Value, item) {
+		h.Remove(n)
+		n.Value = item
+		h.root = h.link(h.root, n)
+		h.len++
+		return
+	}
+	n.Value = item
+	if n == h.root {
+		return
+	}
+	h.cut(n)
+	h.root = h.link(h.root, n)
+}
+
+// Remove removes node n, which must belong to h, from the heap and returns its value.
+// The complexity is O(log n) amortized.
+func (h *PairingHeap[T]) Remove(n *PairingNode[T]) T {
+	if n == h.root {
+		v, _ := h.Pop()
+		return v
+	}
+	h.cut(n)
+	h.root = h.link(h.root, h.mergePairs(n.child))
+	n.child = nil
+	h.len--
+	return n.Value
+}
+
+// cut detaches the subtree rooted at n, a non-root node, from its parent.
+func (h *PairingHeap[T]) cut(n *PairingNode[T]) {
+	if n.prev.child == n {
+		n.prev.child = n.sibling
+	} else {
+		n.prev.sibling = n.sibling
+	}
+	if n.sibling != nil {
+		n.sibling.prev = n.prev
+	}
+	n.prev = nil
+	n.sibling = nil
+}
+
+// link makes the later of two detached roots the leftmost child of the other and returns the new root.
+func (h *PairingHeap[T]) link(a, b *PairingNode[T]) *PairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.less(b.Value, a.Value) {
+		a, b = b, a
+	}
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+// mergePairs links a list of siblings into a single tree using the standard two-pass scheme:
+// pairs are linked left to right, then the results are linked right to left.
+func (h *PairingHeap[T]) mergePairs(first *PairingNode[T]) *PairingNode[T] {
+	// first pass, keeping the linked pairs in a reversed list threaded through sibling
+	var pairs *PairingNode[T]
+	for first != nil {
+		a, b := first, first.sibling
+		first = nil
+		if b != nil {
+			first = b.sibling
+			b.prev, b.sibling = nil, nil
+		}
+		a.prev, a.sibling = nil, nil
+		m := h.link(a, b)
+		m.sibling = pairs
+		pairs = m
+	}
+	// second pass
+	var root *PairingNode[T]
+	for pairs != nil {
+		next := pairs.sibling
+		pairs.sibling = nil
+		root = h.link(root, pairs)
+		pairs = next
+	}
+	return root
+}
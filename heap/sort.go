@@ -0,0 +1,58 @@
+package heap
+
+import (
+	"iter"
+
+	"github.com/danielhookx/xcontainer"
+)
+
+// HeapSort sorts s in ascending order in place.
+// It heapifies s in O(n) and then moves the maximum to the end n times, for O(n log n) overall.
+func HeapSort[T xcontainer.Orderliness](s []T) {
+	h := HeadBuildHeap(s)
+	for h.last > 0 {
+		h.Pop()
+	}
+}
+
+// MergeSorted returns an iterator that merges ascending sequences into one ascending sequence.
+// Equal elements are yielded in the order of the sequences they come from.
+// At most one element per sequence is buffered, so the merge costs O(log k) per element for k sequences.
+func MergeSorted[T xcontainer.Orderliness](seqs ...iter.Seq[T]) iter.Seq[T] {
+	type head struct {
+		val T
+		src int
+	}
+	return func(yield func(T) bool) {
+		nexts := make([]func() (T, bool), len(seqs))
+		for i, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			nexts[i] = next
+		}
+
+		h := NewHeap(func(a, b head) bool {
+			if a.val != b.val {
+				return a.val < b.val
+			}
+			return a.src < b.src
+		})
+		for i, next := range nexts {
+			if v, ok := next(); ok {
+				h.Push(head{v, i})
+			}
+		}
+		for {
+			top, ok := h.Pop()
+			if !ok {
+				return
+			}
+			if !yield(top.val) {
+				return
+			}
+			if v, ok := nexts[top.src](); ok {
+				h.Push(head{v, top.src})
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package heap
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/danielhookx/xcontainer"
+)
+
+// TopK keeps the k largest elements pushed into it, according to less.
+// It is backed by a Heap of at most k elements whose root is the smallest element kept,
+// so every Push costs O(log k) and memory stays bounded however long the stream is.
+type TopK[T any] struct {
+	h    *Heap[T]
+	k    int
+	less func(a, b T) bool
+}
+
+// NewTopK returns an empty TopK keeping the k largest elements according to less.
+// A k below 1 keeps nothing. Memory grows with the elements kept, not with k,
+// so a k larger than the stream simply keeps everything.
+func NewTopK[T any](k int, less func(a, b T) bool) *TopK[T] {
+	return &TopK[T]{
+		h:    NewHeap(less),
+		k:    k,
+		less: less,
+	}
+}
+
+// Push offers item to the TopK and reports whether it was kept.
+// When the TopK is full, item evicts the smallest element kept if it is larger.
+func (t *TopK[T]) Push(item T) bool {
+	if t.h.Len() < t.k {
+		t.h.Push(item)
+		return true
+	}
+	if t.k < 1 || !t.less(t.h.nodes[0], item) {
+		return false
+	}
+	t.h.nodes[0] = item
+	t.h.down(0)
+	return true
+}
+
+// Peek returns the smallest element kept, the one the next larger Push evicts.
+// The boolean is false if the TopK is empty.
+func (t *TopK[T]) Peek() (T, bool) {
+	return t.h.Peek()
+}
+
+// Len returns the number of elements kept.
+func (t *TopK[T]) Len() int {
+	return t.h.Len()
+}
+
+// Cap returns the maximum number of elements kept.
+func (t *TopK[T]) Cap() int {
+	return t.k
+}
+
+// Sorted returns the elements kept from the largest to the smallest.
+// The TopK is not modified.
+func (t *TopK[T]) Sorted() []T {
+	rlt := slices.Clone(t.h.nodes)
+	slices.SortFunc(rlt, func(a, b T) int {
+		switch {
+		case t.less(b, a):
+			return -1
+		case t.less(a, b):
+			return 1
+		default:
+			return 0
+		}
+	})
+	return rlt
+}
+
+// Clear removes all elements kept.
+func (t *TopK[T]) Clear() {
+	t.h.Clear()
+}
+
+// TopKFromFunc returns the k largest elements of seq according to less, from the largest to the smallest.
+func TopKFromFunc[T any](seq iter.Seq[T], k int, less func(a, b T) bool) []T {
+	t := NewTopK(k, less)
+	for item := range seq {
+		t.Push(item)
+	}
+	return t.Sorted()
+}
+
+// TopKFrom returns the k largest elements of seq in descending order.
+func TopKFrom[T xcontainer.Orderliness](seq iter.Seq[T], k int) []T {
+	return TopKFromFunc(seq, k, lessOf[T])
+}
+
+// BottomKFrom returns the k smallest elements of seq in ascending order.
+func BottomKFrom[T xcontainer.Orderliness](seq iter.Seq[T], k int) []T {
+	return TopKFromFunc(seq, k, func(a, b T) bool { return b < a })
+}
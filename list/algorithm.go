@@ -0,0 +1,203 @@
+package list
+
+// Sort sorts the elements of list l in ascending order as determined by cmp,
+// which must return a negative number when a < b, a positive number when a > b
+// and zero when a == b. The sort is stable and runs in O(n log n).
+// Elements are relinked rather than copied, so existing *Element[T] stay valid.
+func (l *List[T]) Sort(cmp func(a, b T) int) {
+	if l.len < 2 {
+		return
+	}
+	head := sortChain(l.root.next, l.len, cmp)
+
+	// rebuild the prev links and the sentinel circle
+	prev := &l.root
+	for e := head; e != nil; e = e.next {
+		e.prev = prev
+		prev.next = e
+		prev = e
+	}
+	prev.next = &l.root
+	l.root.prev = prev
+}
+
+// sortChain merge sorts the chain of n elements starting at head, following next links only.
+// It returns the new head of a nil-terminated chain.
+func sortChain[T any](head *Element[T], n int, cmp func(a, b T) int) *Element[T] {
+	if n == 1 {
+		head.next = nil
+		return head
+	}
+	mid := head
+	for i := 1; i < n/2; i++ {
+		mid = mid.next
+	}
+	right := mid.next
+	left := sortChain(head, n/2, cmp)
+	right = sortChain(right, n-n/2, cmp)
+
+	var merged Element[T]
+	tail := &merged
+	for left != nil && right != nil {
+		// take from left on ties to keep the sort stable
+		if cmp(right.Value, left.Value) < 0 {
+			tail.next, right = right, right.next
+		} else {
+			tail.next, left = left, left.next
+		}
+		tail = tail.next
+	}
+	if left != nil {
+		tail.next = left
+	} else {
+		tail.next = right
+	}
+	return merged.next
+}
+
+// Reverse reverses the order of the elements of list l in place.
+func (l *List[T]) Reverse() {
+	if l.len < 2 {
+		return
+	}
+	e := &l.root
+	for {
+		e.next, e.prev = e.prev, e.next
+		e = e.prev // the old next
+		if e == &l.root {
+			return
+		}
+	}
+}
+
+// SpliceBackList moves all elements of another list to the back of list l, leaving other empty.
+// Unlike PushBackList the elements are moved, not copied, so existing *Element[T] stay valid.
+// If other is l, the list is not modified. The complexity is O(k) for k moved elements,
+// as each element records the list it belongs to.
+func (l *List[T]) SpliceBackList(other *List[T]) {
+	if other == l || other.len == 0 {
+		return
+	}
+	l.lazyInit()
+	l.splice(other, other.root.next, other.root.prev, l.root.prev, other.len)
+}
+
+// SpliceFrontList moves all elements of another list to the front of list l, leaving other empty.
+// Unlike PushFrontList the elements are moved, not copied, so existing *Element[T] stay valid.
+// If other is l, the list is not modified. The complexity is O(k) for k moved elements,
+// as each element records the list it belongs to.
+func (l *List[T]) SpliceFrontList(other *List[T]) {
+	if other == l || other.len == 0 {
+		return
+	}
+	l.lazyInit()
+	l.splice(other, other.root.next, other.root.prev, &l.root, other.len)
+}
+
+// SpliceRange moves the elements from first to last inclusive to list l, right after mark,
+// or to the front of l if mark is nil. The range may belong to l or to another list.
+// If first and last are not in the same list, if mark is not an element of l,
+// or if mark is first or last, the lists are not modified.
+// Within l the move is O(1), like MoveAfter: the caller must make sure that first does not
+// come after last and that mark does not lie inside the range, as this is not checked.
+// From another list it is O(k) for k moved elements, which are walked to record their
+// new list; if first comes after last there, the lists are not modified.
+// The elements and mark must not be nil.
+func (l *List[T]) SpliceRange(first, last, mark *Element[T]) {
+	src := first.list
+	if src == nil || last.list != src || mark != nil && (mark.list != l || mark == first || mark == last) {
+		return
+	}
+	l.lazyInit()
+	at := mark
+	if at == nil {
+		at = &l.root
+	}
+	if src == l {
+		if at.next != first {
+			l.splice(l, first, last, at, 0)
+		}
+		return
+	}
+
+	k := 1
+	for e := first; e != last; e = e.next {
+		if e == &src.root {
+			return
+		}
+		k++
+	}
+	l.splice(src, first, last, at, k)
+}
+
+// splice moves the elements from first to last inclusive out of list src to right after at in l.
+// k is the number of moved elements, which only matters when src is not l.
+// Elements coming from another list are updated to record l.
+func (l *List[T]) splice(src *List[T], first, last, at *Element[T], k int) {
+	// unlink the range from its list
+	first.prev.next = last.next
+	last.next.prev = first.prev
+	src.len -= k
+
+	// link it after at
+	first.prev = at
+	last.next = at.next
+	at.next.prev = last
+	at.next = first
+	l.len += k
+
+	if src != l {
+		for e := first; e != last.next; e = e.next {
+			e.list = l
+		}
+	}
+}
+
+// Find returns the first element of list l, from the front, whose value satisfies pred,
+// or nil if there is none.
+func (l *List[T]) Find(pred func(T) bool) *Element[T] {
+	for e := l.Front(); e != nil; e = e.Next() {
+		if pred(e.Value) {
+			return e
+		}
+	}
+	return nil
+}
+
+// RemoveIf removes every element of list l whose value satisfies pred
+// and returns the number of removed elements.
+func (l *List[T]) RemoveIf(pred func(T) bool) int {
+	n := 0
+	for e := l.Front(); e != nil; {
+		next := e.Next()
+		if pred(e.Value) {
+			l.remove(e)
+			n++
+		}
+		e = next
+	}
+	return n
+}
+
+// Clear removes all elements from list l.
+// Unlike Init it detaches every element, so stale *Element[T] no longer refer to l.
+// The complexity is O(n).
+func (l *List[T]) Clear() {
+	for e := l.Front(); e != nil; {
+		next := e.Next()
+		e.next = nil // avoid memory leaks
+		e.prev = nil // avoid memory leaks
+		e.list = nil
+		e = next
+	}
+	l.Init()
+}
+
+// Values returns the values of list l from front to back in a new slice.
+func (l *List[T]) Values() []T {
+	dst := make([]T, 0, l.len)
+	for e := l.Front(); e != nil; e = e.Next() {
+		dst = append(dst, e.Value)
+	}
+	return dst
+}
@@ -69,10 +69,11 @@ func (l *List[T]) Back() *Element[T] {
 	return l.root.prev
 }
 
-// Iter returns an iterator over the elements of the list.
+// Iter returns an iterator over the values of the list from front to back.
+// Changes made to the list during iteration follow the rules of Elements.
 func (l *List[T]) Iter() iter.Seq[T] {
 	return func(yield func(T) bool) {
-		for e := l.Front(); e != nil; e = e.Next() {
+		for e := range l.Elements() {
 			if !yield(e.Value) {
 				return
 			}
@@ -80,6 +81,65 @@ func (l *List[T]) Iter() iter.Seq[T] {
 	}
 }
 
+// Backward returns an iterator over the values of the list from back to front.
+// Changes made to the list during iteration follow the rules of BackwardElements.
+func (l *List[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := range l.BackwardElements() {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the positions and values of the list from front to back.
+// Positions count the yielded values from 0, so they match the indexes of the list
+// only as long as it is not changed during iteration.
+func (l *List[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for e := range l.Elements() {
+			if !yield(i, e.Value) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Elements returns an iterator over the elements of the list from front to back.
+// The yielded element may be removed or moved during iteration: the iterator
+// remembers the element that followed it and resumes from there, wherever that
+// element is then. Iteration stops if the remembered element is removed from the list.
+// Elements inserted during iteration are visited only if they end up after it.
+func (l *List[T]) Elements() iter.Seq[*Element[T]] {
+	return func(yield func(*Element[T]) bool) {
+		for e := l.Front(); e != nil; {
+			next := e.Next()
+			if !yield(e) || next != nil && next.list != l {
+				return
+			}
+			e = next
+		}
+	}
+}
+
+// BackwardElements returns an iterator over the elements of the list from back to front.
+// It behaves like Elements in the opposite direction: the yielded element may be
+// removed or moved, and iteration resumes from the element that preceded it.
+func (l *List[T]) BackwardElements() iter.Seq[*Element[T]] {
+	return func(yield func(*Element[T]) bool) {
+		for e := l.Back(); e != nil; {
+			prev := e.Prev()
+			if !yield(e) || prev != nil && prev.list != l {
+				return
+			}
+			e = prev
+		}
+	}
+}
+
 // lazyInit lazily initializes a zero List[T] value.
 func (l *List[T]) lazyInit() {
 	if l.root.next == nil {
@@ -173,6 +233,52 @@ func (l *List[T]) InsertAfter(v T, mark *Element[T]) *Element[T] {
 	return l.insertValue(v, mark)
 }
 
+// PushFrontElement inserts the caller-owned element e at the front of list l and returns e.
+// It lets elements be embedded in other structs or reused through a Pool, so no
+// allocation takes place. The element keeps its Value.
+// If e is already an element of a list, the list is not modified and nil is returned.
+// The element must not be nil.
+func (l *List[T]) PushFrontElement(e *Element[T]) *Element[T] {
+	if e.list != nil {
+		return nil
+	}
+	l.lazyInit()
+	return l.insert(e, &l.root)
+}
+
+// PushBackElement inserts the caller-owned element e at the back of list l and returns e.
+// It lets elements be embedded in other structs or reused through a Pool, so no
+// allocation takes place. The element keeps its Value.
+// If e is already an element of a list, the list is not modified and nil is returned.
+// The element must not be nil.
+func (l *List[T]) PushBackElement(e *Element[T]) *Element[T] {
+	if e.list != nil {
+		return nil
+	}
+	l.lazyInit()
+	return l.insert(e, l.root.prev)
+}
+
+// InsertElementBefore inserts the caller-owned element e immediately before mark and returns e.
+// If e is already an element of a list or mark is not an element of l, the list is not
+// modified and nil is returned. The element and mark must not be nil.
+func (l *List[T]) InsertElementBefore(e, mark *Element[T]) *Element[T] {
+	if e.list != nil || mark.list != l {
+		return nil
+	}
+	return l.insert(e, mark.prev)
+}
+
+// InsertElementAfter inserts the caller-owned element e immediately after mark and returns e.
+// If e is already an element of a list or mark is not an element of l, the list is not
+// modified and nil is returned. The element and mark must not be nil.
+func (l *List[T]) InsertElementAfter(e, mark *Element[T]) *Element[T] {
+	if e.list != nil || mark.list != l {
+		return nil
+	}
+	return l.insert(e, mark)
+}
+
 // MoveToFront moves element e to the front of list l.
 // If e is not an element of l, the list is not modified.
 // The element must not be nil.
@@ -5,7 +5,11 @@
 
 package list
 
-import "testing"
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
 
 func checkListLen[T any](t *testing.T, l *List[T], len int) bool {
 	if n := l.Len(); n != len {
@@ -373,3 +377,490 @@ func TestIterate(t *testing.T) {
 		t.Errorf("count = %d, want 2", count)
 	}
 }
+
+// checkListValues checks the links of l and that its values are es, front to back.
+func checkListValues[T comparable](t *testing.T, l *List[T], es []T) {
+	t.Helper()
+	elems := make([]*Element[T], 0, l.Len())
+	for e := l.Front(); e != nil && len(elems) <= l.Len(); e = e.Next() {
+		elems = append(elems, e)
+	}
+	checkListPointers(t, l, elems)
+	if !slices.Equal(l.Values(), es) {
+		t.Errorf("l.Values() = %v, want %v", l.Values(), es)
+	}
+}
+
+func TestSort(t *testing.T) {
+	type item struct{ key, seq int }
+	var l List[item]
+	l.Sort(func(a, b item) int { return a.key - b.key })
+	checkListValues(t, &l, []item{})
+
+	keys := []int{5, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5}
+	elems := make(map[item]*Element[item])
+	for i, k := range keys {
+		it := item{k, i}
+		elems[it] = l.PushBack(it)
+	}
+	l.Sort(func(a, b item) int { return a.key - b.key })
+
+	want := make([]item, 0, len(keys))
+	for i, k := range keys {
+		want = append(want, item{k, i})
+	}
+	slices.SortStableFunc(want, func(a, b item) int { return a.key - b.key })
+	checkListValues(t, &l, want)
+
+	// elements are relinked, not copied
+	for it, e := range elems {
+		if e.Value != it || e.list != &l {
+			t.Errorf("element %v was not kept", it)
+		}
+	}
+
+	var r List[int]
+	for i := 0; i < 1000; i++ {
+		r.PushBack(rand.Intn(100))
+	}
+	r.Sort(func(a, b int) int { return a - b })
+	if !slices.IsSorted(r.Values()) {
+		t.Errorf("list is not sorted: %v", r.Values())
+	}
+	checkListLen(t, &r, 1000)
+}
+
+func TestReverse(t *testing.T) {
+	var l List[int]
+	l.Reverse()
+	checkListValues(t, &l, []int{})
+	l.PushBack(1)
+	l.Reverse()
+	checkListValues(t, &l, []int{1})
+	l.PushBack(2)
+	l.PushBack(3)
+	l.Reverse()
+	checkListValues(t, &l, []int{3, 2, 1})
+	l.PushFront(4)
+	l.Reverse()
+	checkListValues(t, &l, []int{1, 2, 3, 4})
+}
+
+func TestSpliceList(t *testing.T) {
+	l1 := New[int]()
+	l1.PushBack(1)
+	l1.PushBack(2)
+	l2 := New[int]()
+	e3 := l2.PushBack(3)
+	l2.PushBack(4)
+
+	l1.SpliceBackList(l2)
+	checkListValues(t, l1, []int{1, 2, 3, 4})
+	checkListValues(t, l2, []int{})
+	if e3.list != l1 {
+		t.Errorf("moved element does not belong to its new list")
+	}
+	l2.Remove(e3) // no effect, e3 belongs to l1
+	checkListValues(t, l1, []int{1, 2, 3, 4})
+
+	l2.PushBack(0)
+	l1.SpliceFrontList(l2)
+	checkListValues(t, l1, []int{0, 1, 2, 3, 4})
+
+	var empty List[int]
+	empty.SpliceBackList(l1)
+	checkListValues(t, &empty, []int{0, 1, 2, 3, 4})
+	checkListValues(t, l1, []int{})
+	empty.SpliceBackList(&empty)
+	checkListValues(t, &empty, []int{0, 1, 2, 3, 4})
+}
+
+func TestSpliceRange(t *testing.T) {
+	l := New[int]()
+	es := make([]*Element[int], 0)
+	for i := 0; i < 6; i++ {
+		es = append(es, l.PushBack(i))
+	}
+
+	// within the same list
+	l.SpliceRange(es[1], es[2], es[4])
+	checkListValues(t, l, []int{0, 3, 4, 1, 2, 5})
+	l.SpliceRange(es[1], es[2], nil)
+	checkListValues(t, l, []int{1, 2, 0, 3, 4, 5})
+	l.SpliceRange(es[0], es[0], es[2]) // already in place
+	checkListValues(t, l, []int{1, 2, 0, 3, 4, 5})
+
+	l.SpliceRange(es[4], es[5], es[2])
+	checkListValues(t, l, []int{1, 2, 4, 5, 0, 3})
+	l.SpliceRange(es[0], es[3], es[2])
+	checkListValues(t, l, []int{1, 2, 0, 3, 4, 5})
+
+	// invalid ranges leave the list unchanged
+	l.SpliceRange(es[3], es[5], es[5]) // mark is last
+	l.SpliceRange(es[3], es[5], es[3]) // mark is first
+	checkListValues(t, l, []int{1, 2, 0, 3, 4, 5})
+
+	// from another list
+	other := New[int]()
+	other.PushBack(10)
+	f := other.PushBack(11)
+	g := other.PushBack(12)
+	other.PushBack(13)
+	l.SpliceRange(f, g, es[0])
+	checkListValues(t, l, []int{1, 2, 0, 11, 12, 3, 4, 5})
+	checkListValues(t, other, []int{10, 13})
+	l.SpliceRange(f, f, other.Front())              // mark from another list
+	l.SpliceRange(other.Back(), other.Front(), nil) // last before first
+	checkListValues(t, l, []int{1, 2, 0, 11, 12, 3, 4, 5})
+	checkListValues(t, other, []int{10, 13})
+
+	var empty List[int]
+	empty.SpliceRange(other.Front(), other.Back(), nil)
+	checkListValues(t, &empty, []int{10, 13})
+	checkListValues(t, other, []int{})
+}
+
+func TestFindRemoveIf(t *testing.T) {
+	var l List[int]
+	if e := l.Find(func(int) bool { return true }); e != nil {
+		t.Errorf("Find on an empty list = %v, want nil", e)
+	}
+	for i := 0; i < 10; i++ {
+		l.PushBack(i)
+	}
+	if e := l.Find(func(v int) bool { return v > 3 }); e == nil || e.Value != 4 {
+		t.Errorf("Find = %v, want element 4", e)
+	}
+	if e := l.Find(func(v int) bool { return v > 10 }); e != nil {
+		t.Errorf("Find = %v, want nil", e)
+	}
+	if n := l.RemoveIf(func(v int) bool { return v%3 == 0 }); n != 4 {
+		t.Errorf("RemoveIf = %d, want 4", n)
+	}
+	checkListValues(t, &l, []int{1, 2, 4, 5, 7, 8})
+	if n := l.RemoveIf(func(v int) bool { return v > 100 }); n != 0 {
+		t.Errorf("RemoveIf = %d, want 0", n)
+	}
+}
+
+func TestClear(t *testing.T) {
+	var l List[int]
+	l.Clear()
+	checkListValues(t, &l, []int{})
+	e := l.PushBack(1)
+	l.PushBack(2)
+	l.Clear()
+	checkListValues(t, &l, []int{})
+	if e.list != nil || e.Next() != nil {
+		t.Errorf("cleared element still linked")
+	}
+	l.Remove(e) // no effect on a cleared element
+	l.PushBack(3)
+	checkListValues(t, &l, []int{3})
+}
+
+func TestIterateDirections(t *testing.T) {
+	var l List[int]
+	for range l.Backward() {
+		t.Errorf("Backward on an empty list yielded a value")
+	}
+	for i := 1; i <= 5; i++ {
+		l.PushBack(i)
+	}
+	if got := slices.Collect(l.Backward()); !slices.Equal(got, []int{5, 4, 3, 2, 1}) {
+		t.Errorf("Backward = %v", got)
+	}
+	i := 0
+	for idx, v := range l.All() {
+		if idx != i || v != i+1 {
+			t.Errorf("All yielded (%d, %d), want (%d, %d)", idx, v, i, i+1)
+		}
+		i++
+		if idx == 2 {
+			break
+		}
+	}
+	if i != 3 {
+		t.Errorf("All did not stop at break, %d pairs yielded", i)
+	}
+	elems := slices.Collect(l.Elements())
+	checkListPointers(t, &l, elems)
+	back := slices.Collect(l.BackwardElements())
+	slices.Reverse(back)
+	checkListPointers(t, &l, back)
+}
+
+func TestIterateRemoveCurrent(t *testing.T) {
+	var l List[int]
+	for i := 0; i < 10; i++ {
+		l.PushBack(i)
+	}
+	visited := 0
+	for e := range l.Elements() {
+		visited++
+		if e.Value%2 == 0 {
+			l.Remove(e)
+		}
+	}
+	if visited != 10 {
+		t.Errorf("visited %d elements, want 10", visited)
+	}
+	checkListValues(t, &l, []int{1, 3, 5, 7, 9})
+
+	// evict from the back, as an LRU cache does
+	for e := range l.BackwardElements() {
+		if l.Len() <= 2 {
+			break
+		}
+		l.Remove(e)
+	}
+	checkListValues(t, &l, []int{1, 3})
+}
+
+func TestIterateMoveCurrent(t *testing.T) {
+	var l List[int]
+	for i := 0; i < 5; i++ {
+		l.PushBack(i)
+	}
+	// elements moved behind the iteration are not visited again
+	visited := make([]int, 0)
+	for e := range l.Elements() {
+		visited = append(visited, e.Value)
+		if e.Value%2 == 1 {
+			l.MoveToFront(e)
+		}
+	}
+	if !slices.Equal(visited, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("visited %v", visited)
+	}
+	checkListValues(t, &l, []int{3, 1, 0, 2, 4})
+
+	// an element moved ahead of the iteration is visited again
+	visited = visited[:0]
+	moved := false
+	for e := range l.Elements() {
+		visited = append(visited, e.Value)
+		if e.Value == 1 && !moved {
+			l.MoveToBack(e)
+			moved = true
+		}
+	}
+	if !slices.Equal(visited, []int{3, 1, 0, 2, 4, 1}) {
+		t.Errorf("visited %v", visited)
+	}
+
+	var m List[int]
+	for i := 0; i < 5; i++ {
+		m.PushBack(i)
+	}
+	visited = visited[:0]
+	for e := range m.BackwardElements() {
+		visited = append(visited, e.Value)
+		if e.Value == 3 {
+			m.MoveToBack(e)
+		}
+	}
+	if !slices.Equal(visited, []int{4, 3, 2, 1, 0}) {
+		t.Errorf("visited %v", visited)
+	}
+	checkListValues(t, &m, []int{0, 1, 2, 4, 3})
+}
+
+func TestIterateRemoveNext(t *testing.T) {
+	var l List[int]
+	es := make([]*Element[int], 0)
+	for i := 0; i < 5; i++ {
+		es = append(es, l.PushBack(i))
+	}
+	// removing the element that follows the current one stops the iteration
+	visited := make([]int, 0)
+	for v := range l.Iter() {
+		visited = append(visited, v)
+		if v == 1 {
+			l.Remove(es[2])
+		}
+	}
+	if !slices.Equal(visited, []int{0, 1}) {
+		t.Errorf("visited %v", visited)
+	}
+
+	// elements inserted after the remembered one are visited
+	visited = visited[:0]
+	for v := range l.Iter() {
+		visited = append(visited, v)
+		if v == 0 {
+			l.PushBack(5)
+			l.InsertAfter(10, l.Front()) // right after the current element, skipped
+		}
+	}
+	if !slices.Equal(visited, []int{0, 1, 3, 4, 5}) {
+		t.Errorf("visited %v", visited)
+	}
+}
+
+func TestPushElement(t *testing.T) {
+	var l List[int]
+	e1 := l.PushBackElement(&Element[int]{Value: 1})
+	e0 := l.PushFrontElement(&Element[int]{Value: 0})
+	e3 := l.InsertElementAfter(&Element[int]{Value: 3}, e1)
+	e2 := l.InsertElementBefore(&Element[int]{Value: 2}, e3)
+	checkListPointers(t, &l, []*Element[int]{e0, e1, e2, e3})
+	checkListValues(t, &l, []int{0, 1, 2, 3})
+
+	// elements already in a list and unknown marks are refused
+	var other List[int]
+	if e := other.PushBackElement(e1); e != nil {
+		t.Errorf("PushBackElement of a linked element = %v, want nil", e)
+	}
+	if e := other.PushFrontElement(e1); e != nil {
+		t.Errorf("PushFrontElement of a linked element = %v, want nil", e)
+	}
+	if e := l.InsertElementAfter(new(Element[int]), other.PushBack(9)); e != nil {
+		t.Errorf("InsertElementAfter with an unknown mark = %v, want nil", e)
+	}
+	if e := l.InsertElementBefore(e2, e0); e != nil {
+		t.Errorf("InsertElementBefore of a linked element = %v, want nil", e)
+	}
+	checkListValues(t, &l, []int{0, 1, 2, 3})
+
+	// a removed element may be inserted again, in any list
+	l.Remove(e2)
+	other.PushBackElement(e2)
+	checkListValues(t, &l, []int{0, 1, 3})
+	checkListValues(t, &other, []int{9, 2})
+}
+
+// conn embeds its list element, so tracking it in a list does not allocate.
+type conn struct {
+	id   int
+	elem Element[*conn]
+}
+
+func TestIntrusiveElement(t *testing.T) {
+	conns := make([]conn, 4)
+	var idle List[*conn]
+	for i := range conns {
+		c := &conns[i]
+		c.id = i
+		c.elem.Value = c
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		for i := range conns {
+			idle.PushBackElement(&conns[i].elem)
+		}
+		idle.MoveToFront(&conns[2].elem)
+		for e := idle.Front(); e != nil; e = idle.Front() {
+			idle.Remove(e)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("intrusive list allocates %v times per run", allocs)
+	}
+
+	for i := range conns {
+		idle.PushBackElement(&conns[i].elem)
+	}
+	idle.Remove(&conns[1].elem)
+	ids := make([]int, 0)
+	for c := range idle.Iter() {
+		ids = append(ids, c.id)
+	}
+	if !slices.Equal(ids, []int{0, 2, 3}) {
+		t.Errorf("ids = %v", ids)
+	}
+}
+
+func TestPool(t *testing.T) {
+	var p Pool[*int]
+	var l List[*int]
+	v := new(int)
+	e := l.PushBackElement(p.Get(v))
+	if e.Value != v {
+		t.Errorf("pooled element does not hold its value")
+	}
+
+	// elements still in a list are not pooled
+	p.Put(e)
+	if p.Len() != 0 {
+		t.Errorf("p.Len() = %d, want 0", p.Len())
+	}
+	l.Remove(e)
+	p.Put(e)
+	if p.Len() != 1 || e.Value != nil {
+		t.Errorf("removed element was not pooled and cleared")
+	}
+	if got := p.Get(v); got != e || p.Len() != 0 || got.Next() != nil {
+		t.Errorf("Get did not reuse the pooled element")
+	}
+
+	bounded := NewPool[int](2)
+	for i := 0; i < 3; i++ {
+		bounded.Put(&Element[int]{})
+	}
+	if bounded.Len() != 2 {
+		t.Errorf("bounded.Len() = %d, want 2", bounded.Len())
+	}
+
+	// once warmed up, an unbounded pool serves every insertion
+	var q List[int]
+	var unbounded Pool[int]
+	allocs := testing.AllocsPerRun(100, func() {
+		for i := 0; i < 8; i++ {
+			q.PushBackElement(unbounded.Get(i))
+		}
+		for e := q.Front(); e != nil; e = q.Front() {
+			q.Remove(e)
+			unbounded.Put(e)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("pooled list allocates %v times per run", allocs)
+	}
+}
+
+func BenchmarkPushBackRemove(b *testing.B) {
+	var l List[int]
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.PushBack(i)
+		if l.Len() > 64 {
+			l.Remove(l.Front())
+		}
+	}
+}
+
+func BenchmarkPushBackRemovePooled(b *testing.B) {
+	var l List[int]
+	var p Pool[int]
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.PushBackElement(p.Get(i))
+		if l.Len() > 64 {
+			e := l.Front()
+			l.Remove(e)
+			p.Put(e)
+		}
+	}
+}
+
+type packet struct {
+	seq  int
+	elem Element[*packet]
+}
+
+func BenchmarkPushBackRemoveIntrusive(b *testing.B) {
+	var l List[*packet]
+	packets := make([]packet, 65)
+	for i := range packets {
+		packets[i].elem.Value = &packets[i]
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		p := &packets[i%len(packets)]
+		p.seq = i
+		l.PushBackElement(&p.elem)
+		if l.Len() > 64 {
+			l.Remove(l.Front())
+		}
+	}
+}
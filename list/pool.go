@@ -0,0 +1,50 @@
+package list
+
+// Pool is a free list of elements for reuse, to avoid an allocation per insertion
+// on hot paths. Elements removed from a list are given back with Put and handed
+// out again by Get, to be inserted with PushBackElement and the like.
+// A Pool is not safe for concurrent use.
+// The zero value for Pool is an empty pool ready to use.
+type Pool[T any] struct {
+	free *Element[T] // chained through next
+	len  int
+	max  int
+}
+
+// NewPool returns an empty Pool keeping at most max elements.
+// A max below 1 makes the pool unbounded.
+func NewPool[T any](max int) *Pool[T] {
+	return &Pool[T]{max: max}
+}
+
+// Get returns a detached element holding v, reusing a pooled one if there is any.
+func (p *Pool[T]) Get(v T) *Element[T] {
+	e := p.free
+	if e == nil {
+		return &Element[T]{Value: v}
+	}
+	p.free = e.next
+	e.next = nil
+	e.Value = v
+	p.len--
+	return e
+}
+
+// Put gives e back to the pool. The caller must not use e afterwards, as it will be
+// handed out again by Get. If e is still an element of a list or the pool is full,
+// e is left to the garbage collector instead. The element must not be nil.
+func (p *Pool[T]) Put(e *Element[T]) {
+	if e.list != nil || p.max > 0 && p.len >= p.max {
+		return
+	}
+	e.Value = *new(T) // avoid memory leaks
+	e.prev = nil
+	e.next = p.free
+	p.free = e
+	p.len++
+}
+
+// Len returns the number of elements in the pool.
+func (p *Pool[T]) Len() int {
+	return p.len
+}
@@ -0,0 +1,186 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned by BlockingQueue operations after Close, once no element is left to take.
+var ErrClosed = errors.New("queue: closed")
+
+// BlockingQueue is a FIFO queue safe for concurrent use by multiple goroutines.
+// It wraps a Queue with an optional capacity bound: EnQueue blocks while the queue is full
+// and DeQueue blocks while it is empty, until the operation can proceed, the context is
+// done or the queue is closed.
+type BlockingQueue[T any] struct {
+	mu       sync.Mutex
+	q        Queue[T]
+	cap      int
+	closed   bool
+	notEmpty signal // consumers waiting for an element
+	notFull  signal // producers waiting for free space
+}
+
+// NewBlockingQueue returns an empty BlockingQueue holding at most capacity elements.
+// A capacity below 1 makes the queue unbounded.
+func NewBlockingQueue[T any](capacity int) *BlockingQueue[T] {
+	return &BlockingQueue[T]{cap: capacity}
+}
+
+// EnQueue adds v to the back of the queue, waiting for free space if the queue is full.
+// It returns ErrClosed if the queue is closed, or the context error if ctx is done first.
+func (b *BlockingQueue[T]) EnQueue(ctx context.Context, v T) error {
+	for {
+		b.mu.Lock()
+		if b.closed {
+			b.mu.Unlock()
+			return ErrClosed
+		}
+		if !b.full() {
+			b.q.EnQueue(v)
+			b.notEmpty.broadcast()
+			b.mu.Unlock()
+			return nil
+		}
+		changed := b.notFull.wait()
+		b.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			b.mu.Lock()
+			b.notFull.cancel(changed)
+			b.mu.Unlock()
+			return ctx.Err()
+		}
+	}
+}
+
+// DeQueue removes and returns the front element of the queue, waiting for one if the queue is empty.
+// After Close it keeps returning the remaining elements and then ErrClosed.
+// It returns the context error if ctx is done first.
+func (b *BlockingQueue[T]) DeQueue(ctx context.Context) (T, error) {
+	for {
+		b.mu.Lock()
+		if v, ok := b.q.DeQueue(); ok {
+			b.notFull.broadcast()
+			b.mu.Unlock()
+			return v, nil
+		}
+		if b.closed {
+			b.mu.Unlock()
+			return *new(T), ErrClosed
+		}
+		changed := b.notEmpty.wait()
+		b.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			b.mu.Lock()
+			b.notEmpty.cancel(changed)
+			b.mu.Unlock()
+			return *new(T), ctx.Err()
+		}
+	}
+}
+
+// TryEnQueue adds v to the back of the queue without waiting.
+// Returns false if the queue is full or closed.
+func (b *BlockingQueue[T]) TryEnQueue(v T) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed || b.full() {
+		return false
+	}
+	b.q.EnQueue(v)
+	b.notEmpty.broadcast()
+	return true
+}
+
+// TryDeQueue removes and returns the front element of the queue without waiting.
+// The boolean is false if the queue is empty.
+func (b *BlockingQueue[T]) TryDeQueue() (T, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	v, ok := b.q.DeQueue()
+	if ok {
+		b.notFull.broadcast()
+	}
+	return v, ok
+}
+
+// Close stops the queue from accepting elements and wakes up every waiting goroutine.
+// Elements already queued can still be taken with DeQueue and TryDeQueue.
+// Closing a closed queue has no effect.
+func (b *BlockingQueue[T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	b.notEmpty.broadcast()
+	b.notFull.broadcast()
+}
+
+// IsClosed checks whether Close has been called.
+func (b *BlockingQueue[T]) IsClosed() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.closed
+}
+
+// Len returns the number of elements in the queue.
+func (b *BlockingQueue[T]) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.q.Len()
+}
+
+// Cap returns the maximum number of elements the queue holds, or 0 if it is unbounded.
+func (b *BlockingQueue[T]) Cap() int {
+	return max(b.cap, 0)
+}
+
+func (b *BlockingQueue[T]) full() bool {
+	return b.cap > 0 && b.q.Len() >= b.cap
+}
+
+// signal wakes up goroutines waiting for a condition of a concurrent queue.
+// A channel is only created and closed while someone waits, so operations nobody
+// waits for neither allocate nor wake anyone.
+// Its methods must be called with the queue's mutex held.
+// The zero value for signal is ready to use.
+type signal struct {
+	ch      chan struct{}
+	waiters int // goroutines waiting on ch
+}
+
+// wait registers a waiter and returns the channel closed by the next broadcast.
+// A waiter that gives up before the channel is closed must call cancel.
+func (s *signal) wait() <-chan struct{} {
+	if s.ch == nil {
+		s.ch = make(chan struct{})
+	}
+	s.waiters++
+	return s.ch
+}
+
+// cancel unregisters a waiter of ch that stopped waiting for another reason.
+func (s *signal) cancel(ch <-chan struct{}) {
+	if s.ch == ch {
+		s.waiters--
+	}
+}
+
+// broadcast wakes up every registered waiter, if there is any.
+func (s *signal) broadcast() {
+	if s.waiters == 0 {
+		return
+	}
+	close(s.ch)
+	s.ch = nil
+	s.waiters = 0
+}
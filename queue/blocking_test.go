@@ -0,0 +1,175 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockingQueue(t *testing.T) {
+	ctx := context.Background()
+	b := NewBlockingQueue[int](2)
+	assert.Equal(t, 2, b.Cap())
+	assert.True(t, b.TryEnQueue(1))
+	assert.NoError(t, b.EnQueue(ctx, 2))
+	assert.False(t, b.TryEnQueue(3))
+	assert.Equal(t, 2, b.Len())
+
+	// full queue blocks until the context expires
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, b.EnQueue(timeout, 3), context.DeadlineExceeded)
+
+	v, ok := b.TryDeQueue()
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	v, err := b.DeQueue(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+	_, ok = b.TryDeQueue()
+	assert.False(t, ok)
+
+	// empty queue blocks until the context is canceled
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = b.DeQueue(canceled)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	assert.Equal(t, 0, NewBlockingQueue[int](0).Cap())
+}
+
+func TestBlockingQueueWakeUp(t *testing.T) {
+	ctx := context.Background()
+	b := NewBlockingQueue[int](1)
+	got := make(chan int)
+	go func() {
+		v, _ := b.DeQueue(ctx)
+		got <- v
+	}()
+	time.Sleep(10 * time.Millisecond)
+	assert.NoError(t, b.EnQueue(ctx, 7))
+	assert.Equal(t, 7, <-got)
+
+	assert.NoError(t, b.EnQueue(ctx, 1))
+	done := make(chan error)
+	go func() {
+		done <- b.EnQueue(ctx, 2)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	v, _ := b.TryDeQueue()
+	assert.Equal(t, 1, v)
+	assert.NoError(t, <-done)
+	v, _ = b.TryDeQueue()
+	assert.Equal(t, 2, v)
+}
+
+func TestBlockingQueueClose(t *testing.T) {
+	ctx := context.Background()
+	b := NewBlockingQueue[int](0)
+	assert.NoError(t, b.EnQueue(ctx, 1))
+	assert.NoError(t, b.EnQueue(ctx, 2))
+
+	// a waiting consumer of an empty queue is released by Close
+	empty := NewBlockingQueue[int](1)
+	done := make(chan error)
+	go func() {
+		_, err := empty.DeQueue(ctx)
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	empty.Close()
+	assert.ErrorIs(t, <-done, ErrClosed)
+
+	b.Close()
+	b.Close()
+	assert.True(t, b.IsClosed())
+	assert.ErrorIs(t, b.EnQueue(ctx, 3), ErrClosed)
+	assert.False(t, b.TryEnQueue(3))
+
+	// remaining elements are drained before ErrClosed
+	v, err := b.DeQueue(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+	v, ok := b.TryDeQueue()
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+	_, err = b.DeQueue(ctx)
+	assert.ErrorIs(t, err, ErrClosed)
+}
+
+func TestBlockingQueueNoWaiters(t *testing.T) {
+	ctx := context.Background()
+	b := NewBlockingQueue[int](4)
+	b.TryEnQueue(0)
+	b.TryDeQueue()
+
+	// without waiters, operations neither allocate nor signal
+	allocs := testing.AllocsPerRun(100, func() {
+		b.EnQueue(ctx, 1)
+		b.TryEnQueue(2)
+		b.DeQueue(ctx)
+		b.TryDeQueue()
+	})
+	assert.Equal(t, 0.0, allocs)
+
+	// a waiter that gives up is unregistered
+	canceled, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err := b.DeQueue(canceled)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	b.mu.Lock()
+	assert.Equal(t, 0, b.notEmpty.waiters)
+	b.mu.Unlock()
+}
+
+func TestBlockingQueueProducerConsumer(t *testing.T) {
+	const producers, consumers, perProducer = 8, 8, 1000
+	ctx := context.Background()
+	b := NewBlockingQueue[int](16)
+
+	var pwg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		pwg.Add(1)
+		go func(p int) {
+			defer pwg.Done()
+			for i := 0; i < perProducer; i++ {
+				if err := b.EnQueue(ctx, p*perProducer+i); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(p)
+	}
+
+	var mu sync.Mutex
+	seen := make(map[int]int)
+	var cwg sync.WaitGroup
+	for c := 0; c < consumers; c++ {
+		cwg.Add(1)
+		go func() {
+			defer cwg.Done()
+			for {
+				v, err := b.DeQueue(ctx)
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				seen[v]++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	pwg.Wait()
+	b.Close()
+	cwg.Wait()
+	assert.Len(t, seen, producers*perProducer)
+	for v, n := range seen {
+		if n != 1 {
+			t.Errorf("value %d taken %d times", v, n)
+		}
+	}
+}
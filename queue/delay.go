@@ -0,0 +1,135 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	xheap "github.com/danielhookx/xcontainer/heap"
+)
+
+// Clock is the source of time for a DelayQueue.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After returns a channel that receives once d has elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// SystemClock is the Clock backed by the time package.
+var SystemClock Clock = systemClock{}
+
+// DelayQueue is a queue whose elements can be taken only once their ready time has come.
+// Elements are taken in order of ready time, and elements with the same ready time in the
+// order they were added. It is safe for concurrent use by multiple goroutines.
+type DelayQueue[T any] struct {
+	mu      sync.Mutex
+	h       *xheap.Heap[delayEntry[T]]
+	seq     uint64 // insertion counter, breaks ties between equal ready times
+	clock   Clock
+	changed signal // takers waiting for an element or an earlier one
+}
+
+type delayEntry[T any] struct {
+	item    T
+	readyAt time.Time
+	seq     uint64
+}
+
+// NewDelayQueue returns an empty DelayQueue using the system clock.
+func NewDelayQueue[T any]() *DelayQueue[T] {
+	return NewDelayQueueWithClock[T](SystemClock)
+}
+
+// NewDelayQueueWithClock returns an empty DelayQueue reading time from clock.
+func NewDelayQueueWithClock[T any](clock Clock) *DelayQueue[T] {
+	return &DelayQueue[T]{
+		h: xheap.NewHeap(func(a, b delayEntry[T]) bool {
+			if !a.readyAt.Equal(b.readyAt) {
+				return a.readyAt.Before(b.readyAt)
+			}
+			return a.seq < b.seq
+		}),
+		clock: clock,
+	}
+}
+
+// Put adds item to the queue, to be released at readyAt.
+func (d *DelayQueue[T]) Put(item T, readyAt time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	e := delayEntry[T]{item: item, readyAt: readyAt, seq: d.seq}
+	d.h.Push(e)
+	d.seq++
+	// takers only need to wake up if their timers are now too late
+	if head, _ := d.h.Peek(); head.seq == e.seq {
+		d.changed.broadcast()
+	}
+}
+
+// Take removes and returns the earliest element of the queue, waiting until it is ready.
+// It returns the context error if ctx is done first.
+func (d *DelayQueue[T]) Take(ctx context.Context) (T, error) {
+	for {
+		d.mu.Lock()
+		var due <-chan time.Time
+		if e, ok := d.h.Peek(); ok {
+			wait := e.readyAt.Sub(d.clock.Now())
+			if wait <= 0 {
+				d.h.Pop()
+				d.mu.Unlock()
+				return e.item, nil
+			}
+			due = d.clock.After(wait)
+		}
+		changed := d.changed.wait()
+		d.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-due:
+			d.mu.Lock()
+			d.changed.cancel(changed)
+			d.mu.Unlock()
+		case <-ctx.Done():
+			d.mu.Lock()
+			d.changed.cancel(changed)
+			d.mu.Unlock()
+			return *new(T), ctx.Err()
+		}
+	}
+}
+
+// Poll removes and returns the earliest element of the queue without waiting.
+// The boolean is false if the queue is empty or its earliest element is not ready yet.
+func (d *DelayQueue[T]) Poll() (T, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	e, ok := d.h.Peek()
+	if !ok || e.readyAt.After(d.clock.Now()) {
+		return *new(T), false
+	}
+	d.h.Pop()
+	return e.item, true
+}
+
+// Peek returns the earliest element of the queue and its ready time without removing it,
+// whether it is ready or not. The boolean is false if the queue is empty.
+func (d *DelayQueue[T]) Peek() (T, time.Time, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	e, ok := d.h.Peek()
+	return e.item, e.readyAt, ok
+}
+
+// Len returns the number of elements in the queue, ready or not.
+func (d *DelayQueue[T]) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.h.Len()
+}
@@ -0,0 +1,189 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// manualClock is a Clock that only moves when Advance is called.
+type manualClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []clockWaiter
+}
+
+type clockWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, clockWaiter{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+func (c *manualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	waiting := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			waiting = append(waiting, w)
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = waiting
+}
+
+// blockUntil waits until n timers are pending on the clock.
+func (c *manualClock) blockUntil(n int) {
+	for {
+		c.mu.Lock()
+		pending := len(c.waiters)
+		c.mu.Unlock()
+		if pending >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestDelayQueuePoll(t *testing.T) {
+	clock := &manualClock{now: time.Unix(1000, 0)}
+	d := NewDelayQueueWithClock[string](clock)
+	_, ok := d.Poll()
+	assert.False(t, ok)
+
+	start := clock.Now()
+	d.Put("c", start.Add(3*time.Second))
+	d.Put("a", start.Add(time.Second))
+	d.Put("b1", start.Add(2*time.Second))
+	d.Put("b2", start.Add(2*time.Second))
+	assert.Equal(t, 4, d.Len())
+
+	v, at, ok := d.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, "a", v)
+	assert.Equal(t, start.Add(time.Second), at)
+	_, ok = d.Poll()
+	assert.False(t, ok)
+
+	clock.Advance(2 * time.Second)
+	got := make([]string, 0)
+	for v, ok := d.Poll(); ok; v, ok = d.Poll() {
+		got = append(got, v)
+	}
+	assert.EqualValues(t, []string{"a", "b1", "b2"}, got)
+	assert.Equal(t, 1, d.Len())
+
+	clock.Advance(time.Second)
+	v, ok = d.Poll()
+	assert.True(t, ok)
+	assert.Equal(t, "c", v)
+}
+
+func TestDelayQueueTake(t *testing.T) {
+	ctx := context.Background()
+	clock := &manualClock{now: time.Unix(1000, 0)}
+	d := NewDelayQueueWithClock[int](clock)
+	d.Put(1, clock.Now().Add(10*time.Second))
+
+	got := make(chan int)
+	go func() {
+		for i := 0; i < 2; i++ {
+			v, err := d.Take(ctx)
+			assert.NoError(t, err)
+			got <- v
+		}
+	}()
+
+	// an earlier element put while Take waits is released first;
+	// the timer of the first wait stays pending on the clock
+	clock.blockUntil(1)
+	d.Put(2, clock.Now().Add(5*time.Second))
+	clock.blockUntil(2)
+	select {
+	case v := <-got:
+		t.Fatalf("took %d before it was ready", v)
+	default:
+	}
+	clock.Advance(5 * time.Second)
+	assert.Equal(t, 2, <-got)
+	clock.blockUntil(2)
+	clock.Advance(5 * time.Second)
+	assert.Equal(t, 1, <-got)
+
+	// already due elements are taken at once
+	d.Put(3, clock.Now().Add(-time.Second))
+	v, err := d.Take(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v)
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	d.Put(4, clock.Now().Add(time.Hour))
+	_, err = d.Take(timeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestDelayQueueSignal(t *testing.T) {
+	clock := &manualClock{now: time.Unix(1000, 0)}
+	d := NewDelayQueueWithClock[int](clock)
+	d.Put(0, clock.Now().Add(time.Hour))
+
+	// without waiters, Put does not allocate a channel
+	d.mu.Lock()
+	assert.Nil(t, d.changed.ch)
+	d.mu.Unlock()
+
+	done := make(chan int)
+	go func() {
+		v, _ := d.Take(context.Background())
+		done <- v
+	}()
+	waiting := func() int {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		return d.changed.waiters
+	}
+	for waiting() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// a later element leaves the taker asleep, an earlier one wakes it up
+	d.Put(1, clock.Now().Add(2*time.Hour))
+	assert.Equal(t, 1, waiting())
+	d.Put(2, clock.Now().Add(time.Minute))
+	clock.Advance(time.Minute)
+	assert.Equal(t, 2, <-done)
+	assert.Equal(t, 0, waiting())
+}
+
+func TestDelayQueueSystemClock(t *testing.T) {
+	d := NewDelayQueue[int]()
+	start := time.Now()
+	d.Put(1, start.Add(20*time.Millisecond))
+	v, err := d.Take(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+}
@@ -0,0 +1,151 @@
+package queue
+
+import "iter"
+
+// Deque is a double-ended queue backed by a growable circular buffer.
+// Pushing and popping at either end run in amortized O(1) and indexing in O(1).
+// The zero value for Deque is an empty deque ready to use.
+type Deque[T any] struct {
+	buf  []T // len(buf) is zero or a power of two
+	head int // index of the front element
+	len  int
+}
+
+// NewDeque returns an empty deque.
+func NewDeque[T any]() *Deque[T] {
+	return &Deque[T]{}
+}
+
+// PushFront inserts v at the front of the deque.
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = (d.head - 1) & (len(d.buf) - 1)
+	d.buf[d.head] = v
+	d.len++
+}
+
+// PushBack inserts v at the back of the deque.
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[d.index(d.len)] = v
+	d.len++
+}
+
+// PopFront removes and returns the front element of the deque.
+// The boolean is false if the deque is empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	if d.len == 0 {
+		return *new(T), false
+	}
+	v := d.buf[d.head]
+	d.buf[d.head] = *new(T) // avoid memory leaks
+	d.head = d.index(1)
+	d.len--
+	return v, true
+}
+
+// PopBack removes and returns the back element of the deque.
+// The boolean is false if the deque is empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	if d.len == 0 {
+		return *new(T), false
+	}
+	i := d.index(d.len - 1)
+	v := d.buf[i]
+	d.buf[i] = *new(T) // avoid memory leaks
+	d.len--
+	return v, true
+}
+
+// Front returns the front element of the deque without removing it.
+// The boolean is false if the deque is empty.
+func (d *Deque[T]) Front() (T, bool) {
+	if d.len == 0 {
+		return *new(T), false
+	}
+	return d.buf[d.head], true
+}
+
+// Back returns the back element of the deque without removing it.
+// The boolean is false if the deque is empty.
+func (d *Deque[T]) Back() (T, bool) {
+	if d.len == 0 {
+		return *new(T), false
+	}
+	return d.buf[d.index(d.len-1)], true
+}
+
+// At returns the i-th element of the deque, counting from the front.
+// It panics if i is out of range.
+func (d *Deque[T]) At(i int) T {
+	d.checkIndex(i)
+	return d.buf[d.index(i)]
+}
+
+// Set replaces the i-th element of the deque, counting from the front.
+// It panics if i is out of range.
+func (d *Deque[T]) Set(i int, v T) {
+	d.checkIndex(i)
+	d.buf[d.index(i)] = v
+}
+
+// Len returns the number of elements in the deque.
+func (d *Deque[T]) Len() int {
+	return d.len
+}
+
+// Clear removes all elements from the deque, keeping its capacity.
+func (d *Deque[T]) Clear() {
+	clear(d.buf)
+	d.head = 0
+	d.len = 0
+}
+
+// Iter returns an iterator over the elements of the deque from front to back.
+// The deque must not be modified during iteration.
+func (d *Deque[T]) Iter() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < d.len; i++ {
+			if !yield(d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the elements of the deque from back to front.
+// The deque must not be modified during iteration.
+func (d *Deque[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := d.len - 1; i >= 0; i-- {
+			if !yield(d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// index returns the buffer position of the i-th element.
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) & (len(d.buf) - 1)
+}
+
+func (d *Deque[T]) checkIndex(i int) {
+	if i < 0 || i >= d.len {
+		panic("deque: index out of range")
+	}
+}
+
+// grow makes room for one more element.
+func (d *Deque[T]) grow() {
+	if d.len < len(d.buf) {
+		return
+	}
+	buf := make([]T, capacityFor(2*len(d.buf)))
+	if d.len > 0 {
+		k := copy(buf, d.buf[d.head:min(d.head+d.len, len(d.buf))])
+		copy(buf[k:], d.buf[:d.len-k])
+	}
+	d.buf = buf
+	d.head = 0
+}
@@ -0,0 +1,83 @@
+package queue
+
+import "sync/atomic"
+
+// LockFreeQueue is an unbounded multi-producer/multi-consumer FIFO queue safe for concurrent use.
+// It is the Michael-Scott queue: a singly linked list with a sentinel head whose links are
+// updated with compare-and-swap, so no goroutine ever blocks another. The garbage collector
+// keeps retired nodes alive while they are referenced, which rules out the ABA problem.
+type LockFreeQueue[T any] struct {
+	head atomic.Pointer[lfNode[T]] // sentinel, its successor is the front element
+	tail atomic.Pointer[lfNode[T]] // last node or, transiently, its predecessor
+	len  atomic.Int64
+}
+
+type lfNode[T any] struct {
+	val  T
+	next atomic.Pointer[lfNode[T]]
+}
+
+// NewLockFreeQueue returns an empty LockFreeQueue.
+func NewLockFreeQueue[T any]() *LockFreeQueue[T] {
+	q := &LockFreeQueue[T]{}
+	sentinel := &lfNode[T]{}
+	q.head.Store(sentinel)
+	q.tail.Store(sentinel)
+	return q
+}
+
+// EnQueue adds v to the back of the queue.
+func (q *LockFreeQueue[T]) EnQueue(v T) {
+	n := &lfNode[T]{val: v}
+	for {
+		tail := q.tail.Load()
+		next := tail.next.Load()
+		if tail != q.tail.Load() {
+			continue
+		}
+		if next != nil {
+			// tail is lagging behind, help the other producer finish
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if tail.next.CompareAndSwap(nil, n) {
+			q.tail.CompareAndSwap(tail, n)
+			q.len.Add(1)
+			return
+		}
+	}
+}
+
+// DeQueue removes and returns the front element of the queue.
+// The boolean is false if the queue is empty.
+// The node of the returned element becomes the new sentinel and keeps
+// a reference to it until the next DeQueue.
+func (q *LockFreeQueue[T]) DeQueue() (T, bool) {
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		next := head.next.Load()
+		if head != q.head.Load() {
+			continue
+		}
+		if next == nil {
+			return *new(T), false
+		}
+		if head == tail {
+			// tail is lagging behind, help the producer finish
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		v := next.val
+		if q.head.CompareAndSwap(head, next) {
+			q.len.Add(-1)
+			return v, true
+		}
+	}
+}
+
+// Len returns the number of elements in the queue.
+// Under concurrent use the result is only a snapshot and may be momentarily off.
+func (q *LockFreeQueue[T]) Len() int {
+	return int(max(q.len.Load(), 0))
+}
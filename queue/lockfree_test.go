@@ -0,0 +1,213 @@
+package queue
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockFreeQueue(t *testing.T) {
+	q := NewLockFreeQueue[int]()
+	_, ok := q.DeQueue()
+	assert.False(t, ok)
+	for i := 0; i < 10; i++ {
+		q.EnQueue(i)
+	}
+	assert.Equal(t, 10, q.Len())
+	for i := 0; i < 10; i++ {
+		v, ok := q.DeQueue()
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+	_, ok = q.DeQueue()
+	assert.False(t, ok)
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestLockFreeQueueConcurrent(t *testing.T) {
+	const producers, consumers, perProducer = 8, 8, 2000
+	q := NewLockFreeQueue[int]()
+
+	var pwg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		pwg.Add(1)
+		go func(p int) {
+			defer pwg.Done()
+			for i := 0; i < perProducer; i++ {
+				q.EnQueue(p*perProducer + i)
+			}
+		}(p)
+	}
+
+	results := make([][]int, consumers)
+	done := make(chan struct{})
+	var cwg sync.WaitGroup
+	for c := 0; c < consumers; c++ {
+		cwg.Add(1)
+		go func(c int) {
+			defer cwg.Done()
+			for {
+				v, ok := q.DeQueue()
+				if ok {
+					results[c] = append(results[c], v)
+					continue
+				}
+				select {
+				case <-done:
+					// producers are finished, drain what is left
+					for v, ok := q.DeQueue(); ok; v, ok = q.DeQueue() {
+						results[c] = append(results[c], v)
+					}
+					return
+				default:
+				}
+			}
+		}(c)
+	}
+	pwg.Wait()
+	close(done)
+	cwg.Wait()
+
+	seen := make(map[int]bool)
+	for _, rlt := range results {
+		// values of one producer are taken in the order they were added
+		last := make(map[int]int)
+		for _, v := range rlt {
+			assert.False(t, seen[v], "value %d taken twice", v)
+			seen[v] = true
+			p := v / perProducer
+			if prev, ok := last[p]; ok && prev > v {
+				t.Errorf("producer %d: %d taken after %d", p, v, prev)
+			}
+			last[p] = v
+		}
+	}
+	assert.Len(t, seen, producers*perProducer)
+	assert.Equal(t, 0, q.Len())
+}
+
+// mutexQueue is a Queue guarded by a mutex, the baseline for the lock-free benchmarks.
+type mutexQueue[T any] struct {
+	mu sync.Mutex
+	q  Queue[T]
+}
+
+func (m *mutexQueue[T]) EnQueue(v T) {
+	m.mu.Lock()
+	m.q.EnQueue(v)
+	m.mu.Unlock()
+}
+
+func (m *mutexQueue[T]) DeQueue() (T, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.q.DeQueue()
+}
+
+func BenchmarkMutexQueueParallel(b *testing.B) {
+	q := &mutexQueue[int]{}
+	b.SetParallelism(64)
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			q.EnQueue(i)
+			q.DeQueue()
+		}
+	})
+}
+
+func BenchmarkLockFreeQueueParallel(b *testing.B) {
+	q := NewLockFreeQueue[int]()
+	b.SetParallelism(64)
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			q.EnQueue(i)
+			q.DeQueue()
+		}
+	})
+}
+
+func TestWorkStealingDeque(t *testing.T) {
+	d := NewWorkStealingDeque[int]()
+	_, ok := d.Pop()
+	assert.False(t, ok)
+	_, ok = d.Steal()
+	assert.False(t, ok)
+
+	// enough elements to grow the array a few times
+	for i := 0; i < 100; i++ {
+		d.Push(i)
+	}
+	assert.Equal(t, 100, d.Len())
+	v, _ := d.Steal()
+	assert.Equal(t, 0, v)
+	v, _ = d.Pop()
+	assert.Equal(t, 99, v)
+	for i := 1; i < 50; i++ {
+		v, ok := d.Steal()
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+	for i := 98; i >= 50; i-- {
+		v, ok := d.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+	_, ok = d.Pop()
+	assert.False(t, ok)
+	assert.Equal(t, 0, d.Len())
+}
+
+func TestWorkStealingDequeConcurrent(t *testing.T) {
+	const thieves, total = 8, 20000
+	d := NewWorkStealingDeque[int]()
+	taken := make([][]int, thieves+1)
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < thieves; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for {
+				if v, ok := d.Steal(); ok {
+					taken[i] = append(taken[i], v)
+					continue
+				}
+				select {
+				case <-done:
+					return
+				default:
+				}
+			}
+		}(i)
+	}
+
+	// the owner pushes bursts of work and pops some of it back
+	owner := &taken[thieves]
+	for i := 0; i < total; {
+		for n := 0; n < 64 && i < total; n++ {
+			d.Push(i)
+			i++
+		}
+		for n := 0; n < 16; n++ {
+			if v, ok := d.Pop(); ok {
+				*owner = append(*owner, v)
+			}
+		}
+	}
+	for v, ok := d.Pop(); ok; v, ok = d.Pop() {
+		*owner = append(*owner, v)
+	}
+	close(done)
+	wg.Wait()
+
+	seen := make(map[int]bool)
+	for _, rlt := range taken {
+		for _, v := range rlt {
+			assert.False(t, seen[v], "value %d taken twice", v)
+			seen[v] = true
+		}
+	}
+	assert.Len(t, seen, total)
+}
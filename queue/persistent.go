@@ -0,0 +1,81 @@
+package queue
+
+import (
+	"iter"
+
+	xstack "github.com/danielhookx/xcontainer/stack"
+)
+
+// PersistentQueue is an immutable FIFO queue implemented as a banker's queue:
+// elements are taken from a front list and added to a rear list, and the rear list
+// is reversed into the front one when the front runs out.
+// EnQueue and DeQueue never modify the receiver: they return a new version that shares
+// its lists with the old one. Both run in amortized O(1) as long as each version is
+// dequeued from once; repeatedly dequeuing the same version may redo a reversal.
+// Because no version is ever mutated, any version may be read by many goroutines concurrently.
+// The zero value for PersistentQueue is an empty queue ready to use.
+type PersistentQueue[T any] struct {
+	front xstack.PersistentStack[T] // empty only if the queue is empty
+	rear  xstack.PersistentStack[T] // newest element on top
+}
+
+// NewPersistentQueue returns an empty PersistentQueue.
+func NewPersistentQueue[T any]() *PersistentQueue[T] {
+	return &PersistentQueue[T]{}
+}
+
+// EnQueue returns a new version of the queue with v added to the back.
+func (q *PersistentQueue[T]) EnQueue(v T) *PersistentQueue[T] {
+	if q.front.IsEmpty() {
+		return &PersistentQueue[T]{front: *q.front.Push(v)}
+	}
+	return &PersistentQueue[T]{front: q.front, rear: *q.rear.Push(v)}
+}
+
+// DeQueue returns a new version of the queue without its front element, together with that element.
+// The boolean is false if the queue is empty, in which case q itself is returned.
+func (q *PersistentQueue[T]) DeQueue() (*PersistentQueue[T], T, bool) {
+	front, v, ok := q.front.Pop()
+	if !ok {
+		return q, v, false
+	}
+	if front.IsEmpty() {
+		return &PersistentQueue[T]{front: *q.rear.Reverse()}, v, true
+	}
+	return &PersistentQueue[T]{front: *front, rear: q.rear}, v, true
+}
+
+// Peek returns the front element of the queue.
+// The boolean is false if the queue is empty.
+func (q *PersistentQueue[T]) Peek() (T, bool) {
+	return q.front.Peek()
+}
+
+// Len returns the number of elements in the queue.
+func (q *PersistentQueue[T]) Len() int {
+	return q.front.Len() + q.rear.Len()
+}
+
+// IsEmpty checks whether the queue is empty.
+func (q *PersistentQueue[T]) IsEmpty() bool {
+	return q.front.IsEmpty()
+}
+
+// Iter returns an iterator over the elements of the queue from front to back.
+func (q *PersistentQueue[T]) Iter() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range q.front.Iter() {
+			if !yield(v) {
+				return
+			}
+		}
+		if q.rear.IsEmpty() {
+			return
+		}
+		for v := range q.rear.Reverse().Iter() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package queue
+
+import (
+	"github.com/danielhookx/xcontainer"
+	xheap "github.com/danielhookx/xcontainer/heap"
+)
+
+// PriorityQueue is a queue whose elements are taken in order of priority.
+// In a min queue the lowest priority comes first, in a max queue the highest.
+// Elements of equal priority are taken in the order they were added.
+type PriorityQueue[T any, P xcontainer.Orderliness] struct {
+	h   *xheap.Heap[pqEntry[T, P]]
+	seq uint64 // insertion counter, breaks ties between equal priorities
+}
+
+type pqEntry[T any, P xcontainer.Orderliness] struct {
+	item     T
+	priority P
+	seq      uint64
+}
+
+// NewMinPriorityQueue returns an empty priority queue that takes the lowest priority first.
+func NewMinPriorityQueue[T any, P xcontainer.Orderliness]() *PriorityQueue[T, P] {
+	return newPriorityQueue[T](func(a, b P) bool { return a < b })
+}
+
+// NewMaxPriorityQueue returns an empty priority queue that takes the highest priority first.
+func NewMaxPriorityQueue[T any, P xcontainer.Orderliness]() *PriorityQueue[T, P] {
+	return newPriorityQueue[T](func(a, b P) bool { return a > b })
+}
+
+func newPriorityQueue[T any, P xcontainer.Orderliness](before func(a, b P) bool) *PriorityQueue[T, P] {
+	return &PriorityQueue[T, P]{
+		h: xheap.NewHeap(func(a, b pqEntry[T, P]) bool {
+			if a.priority != b.priority {
+				return before(a.priority, b.priority)
+			}
+			return a.seq < b.seq
+		}),
+	}
+}
+
+// EnQueue adds item with the given priority.
+// The complexity is O(log n).
+func (q *PriorityQueue[T, P]) EnQueue(item T, priority P) {
+	q.h.Push(pqEntry[T, P]{item: item, priority: priority, seq: q.seq})
+	q.seq++
+}
+
+// DeQueue removes and returns the first element of the queue and its priority.
+// The boolean is false if the queue is empty.
+// The complexity is O(log n).
+func (q *PriorityQueue[T, P]) DeQueue() (T, P, bool) {
+	e, ok := q.h.Pop()
+	return e.item, e.priority, ok
+}
+
+// Peek returns the first element of the queue and its priority without removing it.
+// The boolean is false if the queue is empty.
+func (q *PriorityQueue[T, P]) Peek() (T, P, bool) {
+	e, ok := q.h.Peek()
+	return e.item, e.priority, ok
+}
+
+// Len returns the number of elements in the queue.
+func (q *PriorityQueue[T, P]) Len() int {
+	return q.h.Len()
+}
+
+// IsEmpty checks whether the queue is empty.
+func (q *PriorityQueue[T, P]) IsEmpty() bool {
+	return q.h.IsEmpty()
+}
+
+// Clear removes all elements from the queue.
+func (q *PriorityQueue[T, P]) Clear() {
+	q.h.Clear()
+	q.seq = 0
+}
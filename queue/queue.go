@@ -1,28 +1,123 @@
 package queue
 
+import "iter"
+
+// minCapacity is the smallest non-zero capacity of the ring buffer.
+const minCapacity = 8
+
+// Queue is a FIFO queue backed by a growable circular buffer.
+// EnQueue and DeQueue run in amortized O(1), and dequeued slots are cleared so
+// the queue does not keep references to elements it no longer holds.
+// The zero value for Queue is an empty queue ready to use.
 type Queue[T any] struct {
-	queue []T
+	buf  []T // len(buf) is zero or a power of two
+	head int // index of the front element
+	len  int
 }
 
+// NewQueue returns an empty queue.
 func NewQueue[T any]() *Queue[T] {
-	return &Queue[T]{
-		queue: make([]T, 0),
+	return &Queue[T]{}
+}
+
+// NewQueueWithCapacity returns an empty queue able to hold n elements before growing.
+func NewQueueWithCapacity[T any](n int) *Queue[T] {
+	q := &Queue[T]{}
+	if n > 0 {
+		q.resize(n)
 	}
+	return q
 }
 
+// EnQueue adds v to the back of the queue.
 func (q *Queue[T]) EnQueue(v T) {
-	q.queue = append(q.queue, v)
+	if q.len == len(q.buf) {
+		q.resize(2 * len(q.buf))
+	}
+	q.buf[(q.head+q.len)&(len(q.buf)-1)] = v
+	q.len++
 }
 
-func (q *Queue[T]) DeQueue() T {
-	if len(q.queue) == 0 {
-		return *new(T)
+// DeQueue removes and returns the front element of the queue.
+// The boolean is false if the queue is empty.
+func (q *Queue[T]) DeQueue() (T, bool) {
+	if q.len == 0 {
+		return *new(T), false
 	}
-	v := q.queue[0]
-	q.queue = q.queue[1:]
-	return v
+	v := q.buf[q.head]
+	q.buf[q.head] = *new(T) // avoid memory leaks
+	q.head = (q.head + 1) & (len(q.buf) - 1)
+	q.len--
+	return v, true
 }
 
+// Peek returns the front element of the queue without removing it.
+// The boolean is false if the queue is empty.
+func (q *Queue[T]) Peek() (T, bool) {
+	if q.len == 0 {
+		return *new(T), false
+	}
+	return q.buf[q.head], true
+}
+
+// Len returns the number of elements in the queue.
 func (q *Queue[T]) Len() int {
-	return len(q.queue)
+	return q.len
+}
+
+// Cap returns the number of elements the queue can hold before growing.
+func (q *Queue[T]) Cap() int {
+	return len(q.buf)
+}
+
+// Clear removes all elements from the queue, keeping its capacity.
+func (q *Queue[T]) Clear() {
+	clear(q.buf)
+	q.head = 0
+	q.len = 0
+}
+
+// Shrink reduces the capacity of the queue to the smallest power of two that holds its elements,
+// releasing the memory of a queue that grew during a burst.
+func (q *Queue[T]) Shrink() {
+	if q.len == 0 {
+		q.buf = nil
+		q.head = 0
+		return
+	}
+	if capacityFor(q.len) < len(q.buf) {
+		q.resize(q.len)
+	}
+}
+
+// Iter returns an iterator over the elements of the queue from front to back.
+// The queue must not be modified during iteration.
+func (q *Queue[T]) Iter() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < q.len; i++ {
+			if !yield(q.buf[(q.head+i)&(len(q.buf)-1)]) {
+				return
+			}
+		}
+	}
+}
+
+// resize moves the elements into a new buffer able to hold at least n elements.
+func (q *Queue[T]) resize(n int) {
+	buf := make([]T, capacityFor(n))
+	if q.len > 0 {
+		k := copy(buf, q.buf[q.head:min(q.head+q.len, len(q.buf))])
+		copy(buf[k:], q.buf[:q.len-k])
+	}
+	q.buf = buf
+	q.head = 0
+}
+
+// capacityFor returns the smallest valid buffer length holding n elements.
+func capacityFor(n int) int {
+	c := minCapacity
+	for c < n {
+		c <<= 1
+	}
+	return c
 }
@@ -1,7 +1,11 @@
 package queue
 
 import (
+	"math/rand"
+	"slices"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestNewQueueInt(t *testing.T) {
@@ -17,3 +21,349 @@ func TestNewQueueInt(t *testing.T) {
 	t.Log(queue.DeQueue())
 	t.Log(queue.DeQueue())
 }
+
+func TestQueue(t *testing.T) {
+	var q Queue[int]
+	_, ok := q.DeQueue()
+	assert.False(t, ok)
+	_, ok = q.Peek()
+	assert.False(t, ok)
+
+	// interleave to make the ring wrap around several times while growing
+	next, want := 0, 0
+	for round := 0; round < 50; round++ {
+		for i := 0; i < round+3; i++ {
+			q.EnQueue(next)
+			next++
+		}
+		for i := 0; i < round+1; i++ {
+			v, ok := q.Peek()
+			assert.True(t, ok)
+			assert.Equal(t, want, v)
+			v, ok = q.DeQueue()
+			assert.True(t, ok)
+			assert.Equal(t, want, v)
+			want++
+		}
+	}
+	assert.Equal(t, next-want, q.Len())
+	rlt := slices.Collect(q.Iter())
+	assert.Len(t, rlt, q.Len())
+	for i, v := range rlt {
+		assert.Equal(t, want+i, v)
+	}
+	for want < next {
+		v, _ := q.DeQueue()
+		assert.Equal(t, want, v)
+		want++
+	}
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestQueueNoLeak(t *testing.T) {
+	q := NewQueue[*int]()
+	for i := 0; i < 10; i++ {
+		v := i
+		q.EnQueue(&v)
+	}
+	for i := 0; i < 5; i++ {
+		q.DeQueue()
+	}
+	nils := 0
+	for _, p := range q.buf {
+		if p == nil {
+			nils++
+		}
+	}
+	assert.Equal(t, len(q.buf)-5, nils)
+}
+
+func TestQueueClearShrink(t *testing.T) {
+	q := NewQueueWithCapacity[int](100)
+	assert.Equal(t, 128, q.Cap())
+	for i := 0; i < 1000; i++ {
+		q.EnQueue(i)
+	}
+	assert.Equal(t, 1024, q.Cap())
+	for i := 0; i < 990; i++ {
+		q.DeQueue()
+	}
+	q.Shrink()
+	assert.Equal(t, minCapacity*2, q.Cap())
+	assert.EqualValues(t, []int{990, 991, 992, 993, 994, 995, 996, 997, 998, 999}, slices.Collect(q.Iter()))
+
+	q.Clear()
+	assert.Equal(t, 0, q.Len())
+	assert.Equal(t, minCapacity*2, q.Cap())
+	q.Shrink()
+	assert.Equal(t, 0, q.Cap())
+	q.EnQueue(1)
+	v, _ := q.DeQueue()
+	assert.Equal(t, 1, v)
+}
+
+// sliceQueue is the former slice backed implementation, kept as a benchmark baseline.
+type sliceQueue[T any] struct {
+	queue []T
+}
+
+func (q *sliceQueue[T]) EnQueue(v T) {
+	q.queue = append(q.queue, v)
+}
+
+func (q *sliceQueue[T]) DeQueue() T {
+	if len(q.queue) == 0 {
+		return *new(T)
+	}
+	v := q.queue[0]
+	q.queue = q.queue[1:]
+	return v
+}
+
+const benchmarkQueueSize = 1024
+
+func BenchmarkSliceQueue(b *testing.B) {
+	q := &sliceQueue[int]{}
+	for i := 0; i < benchmarkQueueSize; i++ {
+		q.EnQueue(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q.EnQueue(i)
+		q.DeQueue()
+	}
+}
+
+func BenchmarkRingQueue(b *testing.B) {
+	q := NewQueue[int]()
+	for i := 0; i < benchmarkQueueSize; i++ {
+		q.EnQueue(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q.EnQueue(i)
+		q.DeQueue()
+	}
+}
+
+func BenchmarkSliceQueueBurst(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		q := &sliceQueue[int]{}
+		for j := 0; j < benchmarkQueueSize; j++ {
+			q.EnQueue(j)
+		}
+		for j := 0; j < benchmarkQueueSize; j++ {
+			q.DeQueue()
+		}
+	}
+}
+
+func BenchmarkRingQueueBurst(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		q := NewQueue[int]()
+		for j := 0; j < benchmarkQueueSize; j++ {
+			q.EnQueue(j)
+		}
+		for j := 0; j < benchmarkQueueSize; j++ {
+			q.DeQueue()
+		}
+	}
+}
+
+func TestDeque(t *testing.T) {
+	var d Deque[int]
+	_, ok := d.PopFront()
+	assert.False(t, ok)
+	_, ok = d.PopBack()
+	assert.False(t, ok)
+	_, ok = d.Front()
+	assert.False(t, ok)
+	_, ok = d.Back()
+	assert.False(t, ok)
+
+	for i := 0; i < 10; i++ {
+		d.PushBack(i)
+		d.PushFront(-i - 1)
+	}
+	assert.Equal(t, 20, d.Len())
+	v, _ := d.Front()
+	assert.Equal(t, -10, v)
+	v, _ = d.Back()
+	assert.Equal(t, 9, v)
+	assert.Equal(t, -1, d.At(9))
+	assert.Equal(t, 0, d.At(10))
+	d.Set(10, 100)
+	assert.Equal(t, 100, d.At(10))
+	d.Set(10, 0)
+	assert.Panics(t, func() { d.At(20) })
+	assert.Panics(t, func() { d.At(-1) })
+
+	want := make([]int, 0)
+	for i := -10; i < 10; i++ {
+		want = append(want, i)
+	}
+	assert.EqualValues(t, want, slices.Collect(d.Iter()))
+	slices.Reverse(want)
+	assert.EqualValues(t, want, slices.Collect(d.Backward()))
+
+	v, _ = d.PopFront()
+	assert.Equal(t, -10, v)
+	v, _ = d.PopBack()
+	assert.Equal(t, 9, v)
+	assert.Equal(t, 18, d.Len())
+
+	d.Clear()
+	assert.Equal(t, 0, d.Len())
+	assert.Empty(t, slices.Collect(d.Iter()))
+}
+
+func TestDequeSlidingWindowMax(t *testing.T) {
+	src := []int{1, 3, -1, -3, 5, 3, 6, 7}
+	const k = 3
+	// d holds indexes of src with decreasing values
+	d := NewDeque[int]()
+	rlt := make([]int, 0)
+	for i, v := range src {
+		if f, ok := d.Front(); ok && f <= i-k {
+			d.PopFront()
+		}
+		for {
+			b, ok := d.Back()
+			if !ok || src[b] > v {
+				break
+			}
+			d.PopBack()
+		}
+		d.PushBack(i)
+		if i >= k-1 {
+			f, _ := d.Front()
+			rlt = append(rlt, src[f])
+		}
+	}
+	assert.EqualValues(t, []int{3, 3, 5, 5, 6, 7}, rlt)
+}
+
+func TestDequeRandom(t *testing.T) {
+	d := NewDeque[int]()
+	want := make([]int, 0)
+	for i := 0; i < 5000; i++ {
+		switch rand.Intn(5) {
+		case 0:
+			d.PushFront(i)
+			want = slices.Insert(want, 0, i)
+		case 1, 2:
+			d.PushBack(i)
+			want = append(want, i)
+		case 3:
+			v, ok := d.PopFront()
+			assert.Equal(t, len(want) > 0, ok)
+			if ok {
+				assert.Equal(t, want[0], v)
+				want = want[1:]
+			}
+		default:
+			v, ok := d.PopBack()
+			assert.Equal(t, len(want) > 0, ok)
+			if ok {
+				assert.Equal(t, want[len(want)-1], v)
+				want = want[:len(want)-1]
+			}
+		}
+	}
+	assert.EqualValues(t, want, slices.Collect(d.Iter()))
+}
+
+func TestMinPriorityQueue(t *testing.T) {
+	q := NewMinPriorityQueue[string, int]()
+	_, _, ok := q.DeQueue()
+	assert.False(t, ok)
+	q.EnQueue("c", 3)
+	q.EnQueue("a1", 1)
+	q.EnQueue("b", 2)
+	q.EnQueue("a2", 1)
+	q.EnQueue("a3", 1)
+	assert.Equal(t, 5, q.Len())
+
+	v, p, ok := q.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, "a1", v)
+	assert.Equal(t, 1, p)
+
+	got := make([]string, 0)
+	for !q.IsEmpty() {
+		v, _, _ := q.DeQueue()
+		got = append(got, v)
+	}
+	assert.EqualValues(t, []string{"a1", "a2", "a3", "b", "c"}, got)
+}
+
+func TestMaxPriorityQueue(t *testing.T) {
+	q := NewMaxPriorityQueue[int, float64]()
+	for i := 0; i < 100; i++ {
+		q.EnQueue(i, float64(i%10))
+	}
+	prevP, prevV := 10.0, -1
+	for i := 0; i < 100; i++ {
+		v, p, ok := q.DeQueue()
+		assert.True(t, ok)
+		if p == prevP {
+			// equal priorities keep FIFO order
+			assert.Greater(t, v, prevV)
+		} else {
+			assert.Less(t, p, prevP)
+		}
+		prevP, prevV = p, v
+	}
+	q.EnQueue(1, 1)
+	q.Clear()
+	assert.Equal(t, 0, q.Len())
+	_, _, ok := q.Peek()
+	assert.False(t, ok)
+}
+
+func TestPersistentQueue(t *testing.T) {
+	var empty PersistentQueue[int]
+	same, _, ok := empty.DeQueue()
+	assert.False(t, ok)
+	assert.Same(t, &empty, same)
+	_, ok = empty.Peek()
+	assert.False(t, ok)
+
+	q := NewPersistentQueue[int]()
+	versions := []*PersistentQueue[int]{q}
+	for i := 1; i <= 5; i++ {
+		q = q.EnQueue(i)
+		versions = append(versions, q)
+	}
+	for i, v := range versions {
+		assert.Equal(t, i, v.Len())
+		assert.EqualValues(t, []int{1, 2, 3, 4, 5}[:i], slices.AppendSeq([]int{}, v.Iter()))
+	}
+
+	q2, v, ok := q.DeQueue()
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	q3 := q2.EnQueue(6)
+	assert.EqualValues(t, []int{2, 3, 4, 5, 6}, slices.Collect(q3.Iter()))
+	assert.EqualValues(t, []int{1, 2, 3, 4, 5}, slices.Collect(q.Iter()))
+
+	// interleaved operations against a slice model
+	want := make([]int, 0)
+	cur := NewPersistentQueue[int]()
+	for i := 0; i < 2000; i++ {
+		if rand.Intn(3) == 0 {
+			next, v, ok := cur.DeQueue()
+			assert.Equal(t, len(want) > 0, ok)
+			if ok {
+				assert.Equal(t, want[0], v)
+				want = want[1:]
+			}
+			cur = next
+			continue
+		}
+		cur = cur.EnQueue(i)
+		want = append(want, i)
+	}
+	assert.Equal(t, len(want), cur.Len())
+	assert.EqualValues(t, want, slices.AppendSeq([]int{}, cur.Iter()))
+}
@@ -0,0 +1,166 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync"
+)
+
+// ErrFull is returned by RingBuffer.Put under the Reject policy when the buffer is full.
+var ErrFull = errors.New("queue: full")
+
+// OverflowPolicy decides what RingBuffer.Put does when the buffer is full.
+type OverflowPolicy int
+
+const (
+	// Overwrite drops the oldest element to make room for the new one.
+	Overwrite OverflowPolicy = iota
+	// Reject refuses the new element with ErrFull.
+	Reject
+	// Block waits until an element is taken or the context is done.
+	Block
+)
+
+// RingBuffer is a circular buffer holding at most a fixed number of elements,
+// ordered from oldest to newest. It is safe for concurrent use by multiple goroutines.
+type RingBuffer[T any] struct {
+	mu      sync.Mutex
+	buf     []T
+	head    int // index of the oldest element
+	len     int
+	policy  OverflowPolicy
+	notFull signal // producers waiting for room under the Block policy
+}
+
+// NewRingBuffer returns an empty RingBuffer holding at most capacity elements
+// and applying policy when full. It panics if capacity is less than 1.
+func NewRingBuffer[T any](capacity int, policy OverflowPolicy) *RingBuffer[T] {
+	if capacity < 1 {
+		panic("ring buffer: capacity must be positive")
+	}
+	return &RingBuffer[T]{
+		buf:    make([]T, capacity),
+		policy: policy,
+	}
+}
+
+// Put adds v as the newest element of the buffer, applying the overflow policy if it is full.
+// Under Reject it returns ErrFull; under Block it returns the context error if ctx is
+// done before room is made. Under Overwrite it always succeeds.
+func (r *RingBuffer[T]) Put(ctx context.Context, v T) error {
+	for {
+		r.mu.Lock()
+		if r.len < len(r.buf) {
+			r.buf[r.index(r.len)] = v
+			r.len++
+			r.mu.Unlock()
+			return nil
+		}
+		switch r.policy {
+		case Overwrite:
+			r.buf[r.head] = v
+			r.head = r.index(1)
+			r.mu.Unlock()
+			return nil
+		case Reject:
+			r.mu.Unlock()
+			return ErrFull
+		}
+		changed := r.notFull.wait()
+		r.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			r.mu.Lock()
+			r.notFull.cancel(changed)
+			r.mu.Unlock()
+			return ctx.Err()
+		}
+	}
+}
+
+// Pop removes and returns the oldest element of the buffer.
+// The boolean is false if the buffer is empty.
+func (r *RingBuffer[T]) Pop() (T, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.len == 0 {
+		return *new(T), false
+	}
+	v := r.buf[r.head]
+	r.buf[r.head] = *new(T) // avoid memory leaks
+	r.head = r.index(1)
+	r.len--
+	r.notFull.broadcast()
+	return v, true
+}
+
+// At returns the i-th element of the buffer, counting from the oldest.
+// It panics if i is out of range.
+func (r *RingBuffer[T]) At(i int) T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if i < 0 || i >= r.len {
+		panic("ring buffer: index out of range")
+	}
+	return r.buf[r.index(i)]
+}
+
+// Len returns the number of elements in the buffer.
+func (r *RingBuffer[T]) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.len
+}
+
+// Cap returns the maximum number of elements the buffer holds.
+func (r *RingBuffer[T]) Cap() int {
+	return len(r.buf)
+}
+
+// IsFull checks whether the buffer holds Cap elements.
+func (r *RingBuffer[T]) IsFull() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.len == len(r.buf)
+}
+
+// Clear removes all elements from the buffer.
+func (r *RingBuffer[T]) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clear(r.buf)
+	r.head = 0
+	r.len = 0
+	r.notFull.broadcast()
+}
+
+// ToSlice returns the elements of the buffer from oldest to newest in a new slice.
+func (r *RingBuffer[T]) ToSlice() []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	dst := make([]T, r.len)
+	k := copy(dst, r.buf[r.head:min(r.head+r.len, len(r.buf))])
+	copy(dst[k:], r.buf[:r.len-k])
+	return dst
+}
+
+// Iter returns an iterator over the elements of the buffer from oldest to newest.
+// It iterates over a snapshot taken when iteration starts, so the buffer may be
+// modified meanwhile.
+func (r *RingBuffer[T]) Iter() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range r.ToSlice() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// index returns the buffer position of the i-th element.
+func (r *RingBuffer[T]) index(i int) int {
+	return (r.head + i) % len(r.buf)
+}
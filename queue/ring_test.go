@@ -0,0 +1,91 @@
+package queue
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRingBufferOverwrite(t *testing.T) {
+	ctx := context.Background()
+	r := NewRingBuffer[int](3, Overwrite)
+	assert.Equal(t, 3, r.Cap())
+	for i := 1; i <= 5; i++ {
+		assert.NoError(t, r.Put(ctx, i))
+	}
+	assert.True(t, r.IsFull())
+	assert.Equal(t, 3, r.Len())
+	assert.EqualValues(t, []int{3, 4, 5}, r.ToSlice())
+	assert.EqualValues(t, []int{3, 4, 5}, slices.Collect(r.Iter()))
+	assert.Equal(t, 3, r.At(0))
+	assert.Equal(t, 5, r.At(2))
+	assert.Panics(t, func() { r.At(3) })
+
+	v, ok := r.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+	assert.NoError(t, r.Put(ctx, 6))
+	assert.EqualValues(t, []int{4, 5, 6}, r.ToSlice())
+
+	r.Clear()
+	_, ok = r.Pop()
+	assert.False(t, ok)
+	assert.Empty(t, r.ToSlice())
+	assert.Panics(t, func() { NewRingBuffer[int](0, Overwrite) })
+}
+
+func TestRingBufferReject(t *testing.T) {
+	ctx := context.Background()
+	r := NewRingBuffer[string](2, Reject)
+	assert.NoError(t, r.Put(ctx, "a"))
+	assert.NoError(t, r.Put(ctx, "b"))
+	assert.ErrorIs(t, r.Put(ctx, "c"), ErrFull)
+	assert.EqualValues(t, []string{"a", "b"}, r.ToSlice())
+}
+
+func TestRingBufferBlock(t *testing.T) {
+	ctx := context.Background()
+	r := NewRingBuffer[int](1, Block)
+	assert.NoError(t, r.Put(ctx, 1))
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, r.Put(timeout, 2), context.DeadlineExceeded)
+
+	done := make(chan error)
+	go func() {
+		done <- r.Put(ctx, 2)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	v, _ := r.Pop()
+	assert.Equal(t, 1, v)
+	assert.NoError(t, <-done)
+	assert.EqualValues(t, []int{2}, r.ToSlice())
+}
+
+func TestRingBufferNoWaiters(t *testing.T) {
+	ctx := context.Background()
+	r := NewRingBuffer[int](2, Block)
+
+	// without blocked producers, Put and Pop neither allocate nor signal
+	allocs := testing.AllocsPerRun(100, func() {
+		r.Put(ctx, 1)
+		r.Put(ctx, 2)
+		r.Pop()
+		r.Pop()
+	})
+	assert.Equal(t, 0.0, allocs)
+
+	// a producer that gives up is unregistered
+	r.Put(ctx, 1)
+	r.Put(ctx, 2)
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, r.Put(timeout, 3), context.DeadlineExceeded)
+	r.mu.Lock()
+	assert.Equal(t, 0, r.notFull.waiters)
+	r.mu.Unlock()
+}
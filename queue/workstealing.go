@@ -0,0 +1,126 @@
+package queue
+
+import "sync/atomic"
+
+// WorkStealingDeque is the Chase-Lev work-stealing deque used by task schedulers.
+// A single owner goroutine pushes and pops at the bottom in LIFO order, while any
+// number of thief goroutines concurrently steal from the top in FIFO order.
+// The owner only synchronizes with thieves when they race for the last element.
+// Elements live in a growable circular array; every index and slot is accessed
+// through sync/atomic, whose sequentially consistent ordering provides the
+// fences the algorithm requires.
+type WorkStealingDeque[T any] struct {
+	top    atomic.Int64 // index of the next element to steal
+	bottom atomic.Int64 // index of the next free slot
+	array  atomic.Pointer[wsArray[T]]
+}
+
+// wsArray is a circular array of capacity a power of two.
+// An array is never shrunk or reused once replaced, so thieves holding a stale
+// array still read consistent values from it.
+type wsArray[T any] struct {
+	slots []atomic.Pointer[T]
+	mask  int64
+}
+
+func newWSArray[T any](size int64) *wsArray[T] {
+	return &wsArray[T]{
+		slots: make([]atomic.Pointer[T], size),
+		mask:  size - 1,
+	}
+}
+
+func (a *wsArray[T]) size() int64 {
+	return a.mask + 1
+}
+
+func (a *wsArray[T]) load(i int64) *T {
+	return a.slots[i&a.mask].Load()
+}
+
+func (a *wsArray[T]) store(i int64, v *T) {
+	a.slots[i&a.mask].Store(v)
+}
+
+// grow returns an array twice the size holding the elements with indexes in [t, b).
+func (a *wsArray[T]) grow(t, b int64) *wsArray[T] {
+	n := newWSArray[T](2 * a.size())
+	for i := t; i < b; i++ {
+		n.store(i, a.load(i))
+	}
+	return n
+}
+
+// NewWorkStealingDeque returns an empty WorkStealingDeque.
+func NewWorkStealingDeque[T any]() *WorkStealingDeque[T] {
+	d := &WorkStealingDeque[T]{}
+	d.array.Store(newWSArray[T](minCapacity))
+	return d
+}
+
+// Push adds v at the bottom of the deque. Only the owner may call it.
+func (d *WorkStealingDeque[T]) Push(v T) {
+	b := d.bottom.Load()
+	t := d.top.Load()
+	a := d.array.Load()
+	if b-t >= a.size() {
+		a = a.grow(t, b)
+		d.array.Store(a)
+	}
+	a.store(b, &v)
+	d.bottom.Store(b + 1)
+}
+
+// Pop removes and returns the bottom element of the deque, the one pushed last.
+// Only the owner may call it. The boolean is false if the deque is empty.
+func (d *WorkStealingDeque[T]) Pop() (T, bool) {
+	b := d.bottom.Load() - 1
+	a := d.array.Load()
+	// reserve the bottom element before looking at top, so a thief either sees
+	// the reservation or is seen by the owner
+	d.bottom.Store(b)
+	t := d.top.Load()
+	if t > b {
+		// empty, undo the reservation
+		d.bottom.Store(b + 1)
+		return *new(T), false
+	}
+	x := a.load(b)
+	if t < b {
+		a.store(b, nil) // avoid memory leaks
+		return *x, true
+	}
+	// last element, race the thieves for it
+	won := d.top.CompareAndSwap(t, t+1)
+	d.bottom.Store(b + 1)
+	if !won {
+		return *new(T), false
+	}
+	return *x, true
+}
+
+// Steal removes and returns the top element of the deque, the oldest one.
+// It is safe to call from any goroutine. The boolean is false if the deque is empty.
+// Slots taken by thieves are not cleared, as the owner may already be reusing them;
+// they are released when overwritten or when the array grows.
+func (d *WorkStealingDeque[T]) Steal() (T, bool) {
+	for {
+		t := d.top.Load()
+		b := d.bottom.Load()
+		if t >= b {
+			return *new(T), false
+		}
+		x := d.array.Load().load(t)
+		if d.top.CompareAndSwap(t, t+1) {
+			return *x, true
+		}
+		// lost the race to another thief or the owner, try again
+	}
+}
+
+// Len returns the number of elements in the deque.
+// Under concurrent use the result is only a snapshot and may be momentarily off.
+func (d *WorkStealingDeque[T]) Len() int {
+	n := d.bottom.Load() - d.top.Load()
+	return int(max(n, 0))
+}
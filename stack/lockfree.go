@@ -0,0 +1,67 @@
+package stack
+
+import "sync/atomic"
+
+// LockFreeStack is an unbounded LIFO stack safe for concurrent use.
+// It is the Treiber stack: a singly linked list whose top is swapped with
+// compare-and-swap, so no goroutine ever blocks another. The garbage collector
+// keeps popped nodes alive while they are referenced, which rules out the ABA problem.
+// The zero value for LockFreeStack is an empty stack ready to use.
+type LockFreeStack[T any] struct {
+	top atomic.Pointer[lfNode[T]]
+	len atomic.Int64
+}
+
+type lfNode[T any] struct {
+	val  T
+	next *lfNode[T]
+}
+
+// NewLockFreeStack returns an empty LockFreeStack.
+func NewLockFreeStack[T any]() *LockFreeStack[T] {
+	return &LockFreeStack[T]{}
+}
+
+// Push adds item to the top of the stack.
+func (s *LockFreeStack[T]) Push(item T) {
+	n := &lfNode[T]{val: item}
+	for {
+		top := s.top.Load()
+		n.next = top
+		if s.top.CompareAndSwap(top, n) {
+			s.len.Add(1)
+			return
+		}
+	}
+}
+
+// Pop removes and returns the top element of the stack.
+// The boolean is false if the stack is empty.
+func (s *LockFreeStack[T]) Pop() (T, bool) {
+	for {
+		top := s.top.Load()
+		if top == nil {
+			return *new(T), false
+		}
+		if s.top.CompareAndSwap(top, top.next) {
+			s.len.Add(-1)
+			return top.val, true
+		}
+	}
+}
+
+// Peek returns the top element of the stack without removing it.
+// The boolean is false if the stack is empty.
+func (s *LockFreeStack[T]) Peek() (T, bool) {
+	top := s.top.Load()
+	if top == nil {
+		return *new(T), false
+	}
+	return top.val, true
+}
+
+// Len returns the number of elements in the stack.
+// Under concurrent use the result is only a snapshot and may be momentarily off.
+func (s *LockFreeStack[T]) Len() int {
+	return int(max(s.len.Load(), 0))
+}
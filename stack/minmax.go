@@ -0,0 +1,129 @@
+package stack
+
+import (
+	"iter"
+
+	"github.com/danielhookx/xcontainer"
+)
+
+// MinStack is a stack that also reports its smallest element in O(1).
+type MinStack[T xcontainer.Orderliness] struct {
+	extremeStack[T]
+}
+
+// NewMinStack returns an empty MinStack.
+func NewMinStack[T xcontainer.Orderliness]() *MinStack[T] {
+	return &MinStack[T]{extremeStack[T]{better: func(a, b T) bool { return a < b }}}
+}
+
+// Min returns the smallest element of the stack.
+// The boolean is false if the stack is empty.
+func (s *MinStack[T]) Min() (T, bool) {
+	return s.extreme()
+}
+
+// MaxStack is a stack that also reports its largest element in O(1).
+type MaxStack[T xcontainer.Orderliness] struct {
+	extremeStack[T]
+}
+
+// NewMaxStack returns an empty MaxStack.
+func NewMaxStack[T xcontainer.Orderliness]() *MaxStack[T] {
+	return &MaxStack[T]{extremeStack[T]{better: func(a, b T) bool { return a > b }}}
+}
+
+// Max returns the largest element of the stack.
+// The boolean is false if the stack is empty.
+func (s *MaxStack[T]) Max() (T, bool) {
+	return s.extreme()
+}
+
+// extremeStack keeps, next to the elements, a stack of the positions of the running extremes:
+// an element's position is pushed there if it is at least as good as the current extreme
+// and popped from there when the element leaves the main stack.
+// Tracking positions rather than values keeps it right for values that are not equal
+// to themselves, such as a float NaN.
+type extremeStack[T xcontainer.Orderliness] struct {
+	items    Stack[T]
+	extremes Stack[int]
+	better   func(a, b T) bool
+}
+
+// Push adds item to the top of the stack.
+func (s *extremeStack[T]) Push(item T) {
+	top, ok := s.extremes.Peek()
+	if !ok || !s.better(s.items.stack[top], item) {
+		s.extremes.Push(s.items.Len())
+	}
+	s.items.Push(item)
+}
+
+// PushAll adds items to the stack in order, so the last one ends up on top.
+func (s *extremeStack[T]) PushAll(items ...T) {
+	for _, item := range items {
+		s.Push(item)
+	}
+}
+
+// Pop removes and returns the top element of the stack.
+// It returns the zero value if the stack is empty; use TryPop to tell the two apart.
+func (s *extremeStack[T]) Pop() T {
+	v, _ := s.TryPop()
+	return v
+}
+
+// TryPop removes and returns the top element of the stack.
+// The boolean is false if the stack is empty.
+func (s *extremeStack[T]) TryPop() (T, bool) {
+	v, ok := s.items.TryPop()
+	if !ok {
+		return v, false
+	}
+	if top, _ := s.extremes.Peek(); top == s.items.Len() {
+		s.extremes.TryPop()
+	}
+	return v, true
+}
+
+// Peek returns the top element of the stack without removing it.
+// The boolean is false if the stack is empty.
+func (s *extremeStack[T]) Peek() (T, bool) {
+	return s.items.Peek()
+}
+
+// extreme returns the current extreme of the stack.
+// The boolean is false if the stack is empty.
+func (s *extremeStack[T]) extreme() (T, bool) {
+	top, ok := s.extremes.Peek()
+	if !ok {
+		return *new(T), false
+	}
+	return s.items.stack[top], true
+}
+
+// Len returns the number of elements in the stack.
+func (s *extremeStack[T]) Len() int {
+	return s.items.Len()
+}
+
+// IsEmpty checks whether the stack is empty.
+func (s *extremeStack[T]) IsEmpty() bool {
+	return s.items.IsEmpty()
+}
+
+// Clear removes all elements from the stack.
+func (s *extremeStack[T]) Clear() {
+	s.items.Clear()
+	s.extremes.Clear()
+}
+
+// Iter returns an iterator over the elements of the stack from top to bottom.
+// The stack must not be modified during iteration.
+func (s *extremeStack[T]) Iter() iter.Seq[T] {
+	return s.items.Iter()
+}
+
+// ToSlice returns the elements of the stack from top to bottom in a new slice.
+func (s *extremeStack[T]) ToSlice() []T {
+	return s.items.ToSlice()
+}
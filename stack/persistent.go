@@ -0,0 +1,81 @@
+package stack
+
+import "iter"
+
+// PersistentStack is an immutable LIFO stack implemented as a linked cons list.
+// Push and Pop never modify the receiver: they return a new version that shares
+// all of its nodes with the old one, so both run in O(1) time and memory.
+// Because no version is ever mutated, any version may be read by many goroutines
+// concurrently, which makes it a natural fit for undo histories and snapshots.
+// The zero value for PersistentStack is an empty stack ready to use.
+type PersistentStack[T any] struct {
+	top *consNode[T]
+	len int
+}
+
+type consNode[T any] struct {
+	val  T
+	next *consNode[T]
+}
+
+// NewPersistentStack returns an empty PersistentStack.
+func NewPersistentStack[T any]() *PersistentStack[T] {
+	return &PersistentStack[T]{}
+}
+
+// Push returns a new version of the stack with item on top.
+func (s *PersistentStack[T]) Push(item T) *PersistentStack[T] {
+	return &PersistentStack[T]{
+		top: &consNode[T]{val: item, next: s.top},
+		len: s.len + 1,
+	}
+}
+
+// Pop returns a new version of the stack without its top element, together with that element.
+// The boolean is false if the stack is empty, in which case s itself is returned.
+func (s *PersistentStack[T]) Pop() (*PersistentStack[T], T, bool) {
+	if s.top == nil {
+		return s, *new(T), false
+	}
+	return &PersistentStack[T]{top: s.top.next, len: s.len - 1}, s.top.val, true
+}
+
+// Peek returns the top element of the stack.
+// The boolean is false if the stack is empty.
+func (s *PersistentStack[T]) Peek() (T, bool) {
+	if s.top == nil {
+		return *new(T), false
+	}
+	return s.top.val, true
+}
+
+// Len returns the number of elements in the stack.
+func (s *PersistentStack[T]) Len() int {
+	return s.len
+}
+
+// IsEmpty checks whether the stack is empty.
+func (s *PersistentStack[T]) IsEmpty() bool {
+	return s.top == nil
+}
+
+// Reverse returns a new stack holding the elements of s in reverse order.
+// The complexity is O(n).
+func (s *PersistentStack[T]) Reverse() *PersistentStack[T] {
+	r := &PersistentStack[T]{len: s.len}
+	for n := s.top; n != nil; n = n.next {
+		r.top = &consNode[T]{val: n.val, next: r.top}
+	}
+	return r
+}
+
+// Iter returns an iterator over the elements of the stack from top to bottom.
+func (s *PersistentStack[T]) Iter() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := s.top; n != nil; n = n.next {
+			if !yield(n.val) {
+				return
+			}
+		}
+	}
+}
@@ -1,28 +1,92 @@
 package stack
 
+import "iter"
+
+// Stack is a LIFO stack backed by a slice.
+// The zero value for Stack is an empty stack ready to use.
 type Stack[T any] struct {
 	stack []T
 }
 
+// NewStack returns an empty stack.
 func NewStack[T any]() *Stack[T] {
 	return &Stack[T]{
 		stack: make([]T, 0),
 	}
 }
 
+// Push adds item to the top of the stack.
 func (s *Stack[T]) Push(item T) {
 	s.stack = append(s.stack, item)
 }
 
+// PushAll adds items to the stack in order, so the last one ends up on top.
+func (s *Stack[T]) PushAll(items ...T) {
+	s.stack = append(s.stack, items...)
+}
+
+// Pop removes and returns the top element of the stack.
+// It returns the zero value if the stack is empty; use TryPop to tell the two apart.
 func (s *Stack[T]) Pop() T {
+	v, _ := s.TryPop()
+	return v
+}
+
+// TryPop removes and returns the top element of the stack.
+// The boolean is false if the stack is empty.
+func (s *Stack[T]) TryPop() (T, bool) {
 	if len(s.stack) == 0 {
-		return *new(T)
+		return *new(T), false
 	}
-	v := s.stack[len(s.stack)-1]
-	s.stack = s.stack[:len(s.stack)-1]
-	return v
+	last := len(s.stack) - 1
+	v := s.stack[last]
+	s.stack[last] = *new(T) // avoid memory leaks
+	s.stack = s.stack[:last]
+	return v, true
+}
+
+// Peek returns the top element of the stack without removing it.
+// The boolean is false if the stack is empty.
+func (s *Stack[T]) Peek() (T, bool) {
+	if len(s.stack) == 0 {
+		return *new(T), false
+	}
+	return s.stack[len(s.stack)-1], true
 }
 
+// Len returns the number of elements in the stack.
 func (s *Stack[T]) Len() int {
 	return len(s.stack)
 }
+
+// IsEmpty checks whether the stack is empty.
+func (s *Stack[T]) IsEmpty() bool {
+	return len(s.stack) == 0
+}
+
+// Clear removes all elements from the stack, keeping its capacity.
+func (s *Stack[T]) Clear() {
+	clear(s.stack)
+	s.stack = s.stack[:0]
+}
+
+// Iter returns an iterator over the elements of the stack from top to bottom.
+// The stack must not be modified during iteration.
+func (s *Stack[T]) Iter() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := len(s.stack) - 1; i >= 0; i-- {
+			if !yield(s.stack[i]) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice returns the elements of the stack from top to bottom in a new slice.
+func (s *Stack[T]) ToSlice() []T {
+	dst := make([]T, 0, len(s.stack))
+	for i := len(s.stack) - 1; i >= 0; i-- {
+		dst = append(dst, s.stack[i])
+	}
+	return dst
+}
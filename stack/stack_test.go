@@ -1,7 +1,12 @@
 package stack
 
 import (
+	"math"
+	"slices"
+	"sync"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestNewStackInt(t *testing.T) {
@@ -17,3 +22,283 @@ func TestNewStackInt(t *testing.T) {
 	t.Log(stack.Pop())
 	t.Log(stack.Pop())
 }
+
+func TestStack(t *testing.T) {
+	var s Stack[int]
+	_, ok := s.TryPop()
+	assert.False(t, ok)
+	_, ok = s.Peek()
+	assert.False(t, ok)
+	assert.True(t, s.IsEmpty())
+
+	// a stored zero is told apart from an empty stack
+	s.Push(0)
+	v, ok := s.TryPop()
+	assert.True(t, ok)
+	assert.Equal(t, 0, v)
+
+	s.PushAll(1, 2, 3)
+	s.Push(4)
+	v, _ = s.Peek()
+	assert.Equal(t, 4, v)
+	assert.EqualValues(t, []int{4, 3, 2, 1}, s.ToSlice())
+	assert.EqualValues(t, []int{4, 3, 2, 1}, slices.Collect(s.Iter()))
+	for v := range s.Iter() {
+		if v == 3 {
+			break
+		}
+	}
+	assert.Equal(t, 4, s.Pop())
+	assert.Equal(t, 3, s.Len())
+
+	s.Clear()
+	assert.Equal(t, 0, s.Len())
+	assert.Empty(t, s.ToSlice())
+}
+
+// nextGreater is a monotonic-stack algorithm: for each element, the next larger element or -1.
+func nextGreater(nums []int) []int {
+	rlt := make([]int, len(nums))
+	s := NewStack[int]() // indexes of elements still waiting for a larger one
+	for i := len(nums) - 1; i >= 0; i-- {
+		for top, ok := s.Peek(); ok && nums[top] <= nums[i]; top, ok = s.Peek() {
+			s.Pop()
+		}
+		rlt[i] = -1
+		if top, ok := s.Peek(); ok {
+			rlt[i] = nums[top]
+		}
+		s.Push(i)
+	}
+	return rlt
+}
+
+func TestStackMonotonic(t *testing.T) {
+	assert.EqualValues(t, []int{4, 4, 5, 5, -1, -1}, nextGreater([]int{2, 1, 4, 3, 5, 0}))
+}
+
+func TestMinStack(t *testing.T) {
+	s := NewMinStack[int]()
+	_, ok := s.Min()
+	assert.False(t, ok)
+	_, ok = s.TryPop()
+	assert.False(t, ok)
+	assert.Equal(t, 0, s.Pop())
+
+	mins := make([]int, 0)
+	for _, v := range []int{5, 3, 7, 3, 1, 8} {
+		s.Push(v)
+		m, _ := s.Min()
+		mins = append(mins, m)
+	}
+	assert.EqualValues(t, []int{5, 3, 3, 3, 1, 1}, mins)
+	assert.Equal(t, 6, s.Len())
+
+	for i := len(mins) - 1; i >= 0; i-- {
+		m, ok := s.Min()
+		assert.True(t, ok)
+		assert.Equal(t, mins[i], m)
+		s.Pop()
+	}
+	assert.True(t, s.IsEmpty())
+
+	s.PushAll(4, 2, 6)
+	assert.EqualValues(t, []int{6, 2, 4}, s.ToSlice())
+	assert.EqualValues(t, []int{6, 2, 4}, slices.Collect(s.Iter()))
+	m, _ := s.Min()
+	assert.Equal(t, 2, m)
+	v, ok := s.TryPop()
+	assert.True(t, ok)
+	assert.Equal(t, 6, v)
+}
+
+func TestMinStackNaN(t *testing.T) {
+	s := NewMinStack[float64]()
+	s.Push(1)
+	s.Push(math.NaN())
+	m, _ := s.Min()
+	assert.True(t, math.IsNaN(m))
+	assert.True(t, math.IsNaN(s.Pop()))
+	m, ok := s.Min()
+	assert.True(t, ok)
+	assert.Equal(t, 1.0, m)
+
+	s.Push(math.NaN())
+	s.Push(0)
+	m, _ = s.Min()
+	assert.Equal(t, 0.0, m)
+	s.Pop()
+	s.Pop()
+	m, _ = s.Min()
+	assert.Equal(t, 1.0, m)
+	s.Pop()
+	_, ok = s.Min()
+	assert.False(t, ok)
+}
+
+func TestMaxStack(t *testing.T) {
+	s := NewMaxStack[string]()
+	s.Push("b")
+	s.Push("a")
+	s.Push("c")
+	m, _ := s.Max()
+	assert.Equal(t, "c", m)
+	v, _ := s.Peek()
+	assert.Equal(t, "c", v)
+	s.Pop()
+	m, _ = s.Max()
+	assert.Equal(t, "b", m)
+	s.Clear()
+	_, ok := s.Max()
+	assert.False(t, ok)
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestPersistentStack(t *testing.T) {
+	var empty PersistentStack[int]
+	same, _, ok := empty.Pop()
+	assert.False(t, ok)
+	assert.Same(t, &empty, same)
+
+	s1 := NewPersistentStack[int]().Push(1)
+	s2 := s1.Push(2)
+	s3 := s2.Push(3)
+	s2b := s2.Push(20)
+
+	// every version keeps its own view
+	assert.EqualValues(t, []int{1}, slices.Collect(s1.Iter()))
+	assert.EqualValues(t, []int{3, 2, 1}, slices.Collect(s3.Iter()))
+	assert.EqualValues(t, []int{20, 2, 1}, slices.Collect(s2b.Iter()))
+	assert.EqualValues(t, []int{1, 2, 3}, slices.Collect(s3.Reverse().Iter()))
+
+	popped, v, ok := s3.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+	assert.Equal(t, 2, popped.Len())
+	assert.Equal(t, 3, s3.Len())
+	top, _ := popped.Peek()
+	assert.Equal(t, 2, top)
+}
+
+func TestPersistentStackUndo(t *testing.T) {
+	// an undo history: each edit pushes a new document version
+	history := NewPersistentStack[string]().Push("")
+	for _, edit := range []string{"a", "ab", "abc"} {
+		history = history.Push(edit)
+	}
+	snapshot := history
+
+	history, _, _ = history.Pop()
+	history, _, _ = history.Pop()
+	doc, _ := history.Peek()
+	assert.Equal(t, "a", doc)
+
+	// the snapshot taken before undoing is unaffected and readable concurrently
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.EqualValues(t, []string{"abc", "ab", "a", ""}, slices.Collect(snapshot.Iter()))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestLockFreeStack(t *testing.T) {
+	var s LockFreeStack[int]
+	_, ok := s.Pop()
+	assert.False(t, ok)
+	_, ok = s.Peek()
+	assert.False(t, ok)
+	for i := 0; i < 10; i++ {
+		s.Push(i)
+	}
+	assert.Equal(t, 10, s.Len())
+	v, _ := s.Peek()
+	assert.Equal(t, 9, v)
+	for i := 9; i >= 0; i-- {
+		v, ok := s.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestLockFreeStackConcurrent(t *testing.T) {
+	const workers, perWorker = 16, 2000
+	s := NewLockFreeStack[int]()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	seen := make(map[int]int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			popped := make([]int, 0, perWorker)
+			for i := 0; i < perWorker; i++ {
+				s.Push(w*perWorker + i)
+				if i%2 == 1 {
+					if v, ok := s.Pop(); ok {
+						popped = append(popped, v)
+					}
+				}
+			}
+			mu.Lock()
+			for _, v := range popped {
+				seen[v]++
+			}
+			mu.Unlock()
+		}(w)
+	}
+	wg.Wait()
+	for v, ok := s.Pop(); ok; v, ok = s.Pop() {
+		seen[v]++
+	}
+	assert.Len(t, seen, workers*perWorker)
+	for v, n := range seen {
+		if n != 1 {
+			t.Errorf("value %d popped %d times", v, n)
+		}
+	}
+}
+
+// mutexStack is a Stack guarded by a mutex, the baseline for the lock-free benchmarks.
+type mutexStack[T any] struct {
+	mu sync.Mutex
+	s  Stack[T]
+}
+
+func (m *mutexStack[T]) Push(item T) {
+	m.mu.Lock()
+	m.s.Push(item)
+	m.mu.Unlock()
+}
+
+func (m *mutexStack[T]) Pop() T {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.s.Pop()
+}
+
+func BenchmarkMutexStackParallel(b *testing.B) {
+	s := &mutexStack[int]{}
+	b.SetParallelism(64)
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			s.Push(i)
+			s.Pop()
+		}
+	})
+}
+
+func BenchmarkLockFreeStackParallel(b *testing.B) {
+	s := NewLockFreeStack[int]()
+	b.SetParallelism(64)
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			s.Push(i)
+			s.Pop()
+		}
+	})
+}
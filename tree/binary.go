@@ -0,0 +1,265 @@
+package tree
+
+import (
+	"iter"
+
+	"github.com/danielhookx/xcontainer"
+	xstack "github.com/danielhookx/xcontainer/stack"
+)
+
+// The helpers below work on any TreeNodeI. They are iterative, so degenerate
+// (list shaped) trees do not grow the goroutine stack.
+
+// Height returns the number of nodes on the longest path from n down to a leaf.
+// The height of an empty tree is 0.
+func Height[T any](n TreeNodeI[T]) int {
+	return heights(n, func(int, int) {})
+}
+
+// Size returns the number of nodes of the tree rooted at n.
+func Size[T any](n TreeNodeI[T]) int {
+	size := 0
+	for range PreOrderIter(n, BinaryChildren[T]) {
+		size++
+	}
+	return size
+}
+
+// LeafCount returns the number of nodes without children of the tree rooted at n.
+func LeafCount[T any](n TreeNodeI[T]) int {
+	count := 0
+	for node := range PreOrderIter(n, BinaryChildren[T]) {
+		if len(BinaryChildren(node)) == 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// IsBalanced reports whether the heights of the two subtrees of every node differ by at most one.
+func IsBalanced[T any](n TreeNodeI[T]) bool {
+	balanced := true
+	heights(n, func(left, right int) {
+		if d := left - right; d > 1 || d < -1 {
+			balanced = false
+		}
+	})
+	return balanced
+}
+
+// Diameter returns the number of edges on the longest path between any two nodes of the tree rooted at n.
+func Diameter[T any](n TreeNodeI[T]) int {
+	diameter := 0
+	heights(n, func(left, right int) {
+		diameter = max(diameter, left+right)
+	})
+	return diameter
+}
+
+// heightFrame is a node of the tree being measured by heights, with the heights
+// of the subtrees measured so far.
+type heightFrame[T any] struct {
+	node        TreeNodeI[T]
+	left, right int
+	next        int // 0: visit the left subtree, 1: the right one, 2: done
+}
+
+// heights walks the tree rooted at n in post-order and calls visit for every node
+// with the heights of its left and right subtrees. Missing (nil) children have height 0.
+// It returns the height of n. Nodes are kept on an explicit stack rather than keyed
+// in a map, so they need not be comparable.
+func heights[T any](n TreeNodeI[T], visit func(left, right int)) int {
+	if xcontainer.IsNil(n) {
+		return 0
+	}
+	frames := []heightFrame[T]{{node: n}}
+	for {
+		f := &frames[len(frames)-1]
+		var child TreeNodeI[T]
+		switch f.next {
+		case 0:
+			child = f.node.Left()
+		case 1:
+			child = f.node.Right()
+		}
+		f.next++
+		if f.next <= 2 {
+			if !xcontainer.IsNil(child) {
+				frames = append(frames, heightFrame[T]{node: child})
+			}
+			continue
+		}
+
+		visit(f.left, f.right)
+		h := max(f.left, f.right) + 1
+		frames = frames[:len(frames)-1]
+		if len(frames) == 0 {
+			return h
+		}
+		// the parent has moved on past the subtree just measured
+		if parent := &frames[len(frames)-1]; parent.next == 1 {
+			parent.left = h
+		} else {
+			parent.right = h
+		}
+	}
+}
+
+// IsBST reports whether the in-order traversal of the tree rooted at n is strictly increasing.
+func IsBST[T xcontainer.Orderliness](n TreeNodeI[T]) bool {
+	first := true
+	var prev T
+	for v := range inOrderIter(n) {
+		if !first && v <= prev {
+			return false
+		}
+		first = false
+		prev = v
+	}
+	return true
+}
+
+// inOrderIter yields the values of the tree rooted at n in in-order.
+func inOrderIter[T any](n TreeNodeI[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		stack := xstack.NewStack[TreeNodeI[T]]()
+		for !xcontainer.IsNil(n) || stack.Len() > 0 {
+			for !xcontainer.IsNil(n) {
+				stack.Push(n)
+				n = n.Left()
+			}
+			n = stack.Pop()
+			if !yield(n.Val()) {
+				return
+			}
+			n = n.Right()
+		}
+	}
+}
+
+// Equal reports whether a and b have the same shape and the same values at every position.
+func Equal[T comparable](a, b TreeNodeI[T]) bool {
+	type pair struct{ a, b TreeNodeI[T] }
+	stack := xstack.NewStack[pair]()
+	stack.Push(pair{a, b})
+	for stack.Len() > 0 {
+		p := stack.Pop()
+		aNil, bNil := xcontainer.IsNil(p.a), xcontainer.IsNil(p.b)
+		if aNil || bNil {
+			if aNil != bNil {
+				return false
+			}
+			continue
+		}
+		if p.a.Val() != p.b.Val() {
+			return false
+		}
+		stack.Push(pair{p.a.Right(), p.b.Right()})
+		stack.Push(pair{p.a.Left(), p.b.Left()})
+	}
+	return true
+}
+
+// Clone returns a copy of the tree rooted at n built from TreeNode values.
+func Clone[T any](n TreeNodeI[T]) *TreeNode[T] {
+	return copyTree(n, false)
+}
+
+// Mirror returns a copy of the tree rooted at n with the left and right children of every node swapped.
+func Mirror[T any](n TreeNodeI[T]) *TreeNode[T] {
+	return copyTree(n, true)
+}
+
+func copyTree[T any](n TreeNodeI[T], mirror bool) *TreeNode[T] {
+	if xcontainer.IsNil(n) {
+		return nil
+	}
+	type pair struct {
+		src TreeNodeI[T]
+		dst *TreeNode[T]
+	}
+	root := &TreeNode[T]{val: n.Val()}
+	stack := xstack.NewStack[pair]()
+	stack.Push(pair{n, root})
+	for stack.Len() > 0 {
+		p := stack.Pop()
+		left, right := p.src.Left(), p.src.Right()
+		if mirror {
+			left, right = right, left
+		}
+		if !xcontainer.IsNil(left) {
+			p.dst.left = &TreeNode[T]{val: left.Val()}
+			stack.Push(pair{left, p.dst.left})
+		}
+		if !xcontainer.IsNil(right) {
+			p.dst.right = &TreeNode[T]{val: right.Val()}
+			stack.Push(pair{right, p.dst.right})
+		}
+	}
+	return root
+}
+
+// Invert swaps the left and right children of every node of the tree rooted at n in place.
+func Invert[T any](n *TreeNode[T]) {
+	if n == nil {
+		return
+	}
+	stack := xstack.NewStack[*TreeNode[T]]()
+	stack.Push(n)
+	for stack.Len() > 0 {
+		node := stack.Pop()
+		node.left, node.right = node.right, node.left
+		if node.left != nil {
+			stack.Push(node.left)
+		}
+		if node.right != nil {
+			stack.Push(node.right)
+		}
+	}
+}
+
+// RootToLeafPaths returns the values along every path from n down to a leaf, from left to right.
+func RootToLeafPaths[T any](n TreeNodeI[T]) [][]T {
+	return collectPaths(n, func([]T) bool { return true })
+}
+
+// PathSum returns every root-to-leaf path of the tree rooted at n whose values add up to target.
+func PathSum[T xcontainer.Int | xcontainer.Uint | xcontainer.Float](n TreeNodeI[T], target T) [][]T {
+	return collectPaths(n, func(path []T) bool {
+		var sum T
+		for _, v := range path {
+			sum += v
+		}
+		return sum == target
+	})
+}
+
+// collectPaths returns the root-to-leaf paths of the tree rooted at n that satisfy keep.
+func collectPaths[T any](n TreeNodeI[T], keep func([]T) bool) [][]T {
+	rlt := make([][]T, 0)
+	if xcontainer.IsNil(n) {
+		return rlt
+	}
+	type frame struct {
+		node  TreeNodeI[T]
+		depth int
+	}
+	path := make([]T, 0)
+	stack := xstack.NewStack[frame]()
+	stack.Push(frame{n, 0})
+	for stack.Len() > 0 {
+		f := stack.Pop()
+		path = append(path[:f.depth], f.node.Val())
+		children := BinaryChildren(f.node)
+		if len(children) == 0 {
+			if keep(path) {
+				rlt = append(rlt, append([]T(nil), path...))
+			}
+			continue
+		}
+		for i := len(children) - 1; i >= 0; i-- {
+			stack.Push(frame{children[i], f.depth + 1})
+		}
+	}
+	return rlt
+}
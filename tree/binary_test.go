@@ -0,0 +1,163 @@
+package tree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newSampleTree() *TreeNode[int] {
+	l1 := []string{"6"}
+	l2 := []string{"1", "3"}
+	l3 := []string{"9", "4", "2", "7"}
+	l4 := []string{"5", "nil", "nil", "nil", "8", "nil", "nil", "nil"}
+	return NewTree[int](l1, l2, l3, l4)
+}
+
+// newChain returns a degenerate tree of n nodes where every node only has a right child.
+func newChain(n int) *TreeNode[int] {
+	root := &TreeNode[int]{val: 0}
+	node := root
+	for i := 1; i < n; i++ {
+		node.right = &TreeNode[int]{val: i}
+		node = node.right
+	}
+	return root
+}
+
+func TestTreeMetrics(t *testing.T) {
+	root := newSampleTree()
+	assert.Equal(t, 4, Height[int](root))
+	assert.Equal(t, 9, Size[int](root))
+	assert.Equal(t, 4, LeafCount[int](root))
+	assert.Equal(t, 6, Diameter[int](root))
+	assert.True(t, IsBalanced[int](root))
+	assert.False(t, IsBST[int](root))
+
+	var empty *TreeNode[int]
+	assert.Equal(t, 0, Height[int](empty))
+	assert.Equal(t, 0, Size[int](empty))
+	assert.Equal(t, 0, LeafCount[int](empty))
+	assert.Equal(t, 0, Diameter[int](empty))
+	assert.True(t, IsBalanced[int](empty))
+	assert.True(t, IsBST[int](empty))
+
+	unbalanced := NewTree[int]([]string{"1"}, []string{"2", "nil"}, []string{"3", "nil", "nil", "nil"})
+	assert.False(t, IsBalanced[int](unbalanced))
+	assert.Equal(t, 3, Height[int](unbalanced))
+	assert.Equal(t, 2, Diameter[int](unbalanced))
+}
+
+// valueNode is a TreeNodeI implemented by a value type, which is neither comparable
+// nor distinguishable from another node with the same contents.
+type valueNode struct {
+	val      int
+	children []valueNode // left then right; a node with one child has only a left one
+}
+
+func (n valueNode) Val() int { return n.val }
+
+func (n valueNode) Left() TreeNodeI[int] {
+	if len(n.children) < 1 {
+		return nil
+	}
+	return n.children[0]
+}
+
+func (n valueNode) Right() TreeNodeI[int] {
+	if len(n.children) < 2 {
+		return nil
+	}
+	return n.children[1]
+}
+
+func TestTreeMetricsValueNodes(t *testing.T) {
+	leaf := valueNode{val: 1}
+	// equal leaves at different depths must not share a height
+	root := valueNode{val: 1, children: []valueNode{
+		{val: 1, children: []valueNode{{val: 1, children: []valueNode{leaf}}}},
+		leaf,
+	}}
+	assert.Equal(t, 4, Height[int](root))
+	assert.Equal(t, 4, Diameter[int](root))
+	assert.False(t, IsBalanced[int](root))
+	assert.Equal(t, 1, Height[int](leaf))
+	assert.True(t, IsBalanced[int](leaf))
+}
+
+func TestIsBST(t *testing.T) {
+	st := &SearchTree[int]{}
+	for _, v := range []int{5, 9, 4, 1, 8, 2, 7, 3, 6} {
+		st.Put(v)
+	}
+	assert.True(t, IsBST[int](st.root))
+
+	// 3 is in the right subtree of 5 but smaller than it
+	bad := NewTree[int]([]string{"5"}, []string{"2", "8"}, []string{"nil", "nil", "3", "9"})
+	assert.False(t, IsBST[int](bad))
+
+	dup := NewTree[int]([]string{"5"}, []string{"5", "nil"})
+	assert.False(t, IsBST[int](dup))
+}
+
+func TestEqualCloneMirror(t *testing.T) {
+	root := newSampleTree()
+	clone := Clone[int](root)
+	assert.True(t, Equal[int](root, clone))
+	assert.NotSame(t, root, clone)
+
+	mirror := Mirror[int](root)
+	assert.False(t, Equal[int](root, mirror))
+	rlt := make([]int, 0)
+	InOrder[int](mirror, &rlt)
+	assert.EqualValues(t, []int{7, 3, 2, 8, 6, 4, 1, 9, 5}, rlt)
+
+	Invert(clone)
+	assert.True(t, Equal[int](mirror, clone))
+	Invert(clone)
+	assert.True(t, Equal[int](root, clone))
+
+	clone.left.val = 100
+	assert.False(t, Equal[int](root, clone))
+	assert.Equal(t, 1, root.left.val)
+
+	var empty *TreeNode[int]
+	assert.True(t, Equal[int](empty, nil))
+	assert.False(t, Equal[int](root, empty))
+	assert.Nil(t, Clone[int](empty))
+	assert.Nil(t, Mirror[int](empty))
+	Invert(empty)
+}
+
+func TestPaths(t *testing.T) {
+	root := newSampleTree()
+	assert.EqualValues(t, [][]int{
+		{6, 1, 9, 5},
+		{6, 1, 4},
+		{6, 3, 2, 8},
+		{6, 3, 7},
+	}, RootToLeafPaths[int](root))
+	assert.EqualValues(t, [][]int{{6, 1, 4}, {6, 3, 2}}, PathSum[int](NewTree[int]([]string{"6"}, []string{"1", "3"}, []string{"nil", "4", "2", "nil"}), 11))
+	assert.EqualValues(t, [][]int{{6, 1, 4}}, PathSum[int](root, 11))
+	assert.EqualValues(t, [][]int{{6, 3, 7}}, PathSum[int](root, 16))
+	assert.Empty(t, PathSum[int](root, 1))
+
+	var empty *TreeNode[int]
+	assert.Empty(t, RootToLeafPaths[int](empty))
+}
+
+func TestDegenerateTree(t *testing.T) {
+	const n = 100000
+	root := newChain(n)
+	assert.Equal(t, n, Height[int](root))
+	assert.Equal(t, n, Size[int](root))
+	assert.Equal(t, 1, LeafCount[int](root))
+	assert.Equal(t, n-1, Diameter[int](root))
+	assert.False(t, IsBalanced[int](root))
+	assert.True(t, IsBST[int](root))
+	clone := Clone[int](root)
+	assert.True(t, Equal[int](root, clone))
+	Invert(clone)
+	assert.True(t, Equal[int](Mirror[int](root), clone))
+	assert.Len(t, RootToLeafPaths[int](root), 1)
+}
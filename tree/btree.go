@@ -0,0 +1,328 @@
+package tree
+
+import (
+	"iter"
+	"slices"
+	"sort"
+
+	"github.com/danielhookx/xcontainer"
+)
+
+// DefaultBTreeDegree is the minimum degree used by a BTree created with a degree below 2
+// or by the zero value of BTree.
+const DefaultBTreeDegree = 32
+
+// BTree is an in-memory B-tree holding distinct ordered values.
+// Every node except the root stores between degree-1 and 2*degree-1 values in a
+// contiguous slice, which keeps lookups cache friendly for large sets.
+// The zero value for BTree is an empty tree ready to use with DefaultBTreeDegree.
+type BTree[T xcontainer.Orderliness] struct {
+	degree int
+	root   *bnode[T]
+	len    int
+}
+
+type bnode[T xcontainer.Orderliness] struct {
+	items    []T
+	children []*bnode[T]
+}
+
+// NewBTree returns an empty BTree with the given minimum degree.
+// A degree below 2 falls back to DefaultBTreeDegree.
+func NewBTree[T xcontainer.Orderliness](degree int) *BTree[T] {
+	if degree < 2 {
+		degree = DefaultBTreeDegree
+	}
+	return &BTree[T]{degree: degree}
+}
+
+// Degree returns the minimum degree of the tree.
+func (t *BTree[T]) Degree() int {
+	if t.degree < 2 {
+		return DefaultBTreeDegree
+	}
+	return t.degree
+}
+
+// Len returns the number of values in the tree.
+// The complexity is O(1).
+func (t *BTree[T]) Len() int { return t.len }
+
+// Has reports whether data is in the tree.
+func (t *BTree[T]) Has(data T) bool {
+	for n := t.root; n != nil; {
+		i, found := n.find(data)
+		if found {
+			return true
+		}
+		if n.leaf() {
+			return false
+		}
+		n = n.children[i]
+	}
+	return false
+}
+
+// Put inserts data into the tree, if it is not present already.
+func (t *BTree[T]) Put(data T) {
+	degree := t.Degree()
+	if t.root == nil {
+		t.root = &bnode[T]{items: []T{data}}
+		t.len++
+		return
+	}
+	if len(t.root.items) >= 2*degree-1 {
+		old := t.root
+		t.root = &bnode[T]{children: []*bnode[T]{old}}
+		t.root.split(0, degree)
+	}
+	if t.root.insert(data, degree) {
+		t.len++
+	}
+}
+
+// Del removes data from the tree, if it is present.
+func (t *BTree[T]) Del(data T) {
+	if t.root == nil {
+		return
+	}
+	if t.root.remove(data, t.Degree()) {
+		t.len--
+	}
+	// shrink the tree when the root runs out of values
+	if len(t.root.items) == 0 {
+		if t.root.leaf() {
+			t.root = nil
+		} else {
+			t.root = t.root.children[0]
+		}
+	}
+}
+
+// Min returns the smallest value in the tree.
+// The boolean is false if the tree is empty.
+func (t *BTree[T]) Min() (T, bool) {
+	if t.root == nil {
+		return *new(T), false
+	}
+	return t.root.min(), true
+}
+
+// Max returns the largest value in the tree.
+// The boolean is false if the tree is empty.
+func (t *BTree[T]) Max() (T, bool) {
+	if t.root == nil {
+		return *new(T), false
+	}
+	return t.root.max(), true
+}
+
+// Iter returns an iterator over the values of the tree in ascending order.
+func (t *BTree[T]) Iter() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if t.root != nil {
+			t.root.ascend(yield)
+		}
+	}
+}
+
+// Range returns an iterator over the values v of the tree with from <= v < to, in ascending order.
+func (t *BTree[T]) Range(from, to T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if t.root != nil && from < to {
+			t.root.ascendRange(from, to, yield)
+		}
+	}
+}
+
+// Clone returns a copy of the tree that shares no nodes with t.
+func (t *BTree[T]) Clone() *BTree[T] {
+	c := &BTree[T]{degree: t.degree, len: t.len}
+	if t.root != nil {
+		c.root = t.root.clone()
+	}
+	return c
+}
+
+func (n *bnode[T]) leaf() bool {
+	return len(n.children) == 0
+}
+
+// find returns the index of the first item not less than data and whether it equals data.
+func (n *bnode[T]) find(data T) (int, bool) {
+	i := sort.Search(len(n.items), func(i int) bool {
+		return n.items[i] >= data
+	})
+	return i, i < len(n.items) && n.items[i] == data
+}
+
+// insert inserts data into the subtree rooted at the non-full node n.
+func (n *bnode[T]) insert(data T, degree int) bool {
+	i, found := n.find(data)
+	if found {
+		return false
+	}
+	if n.leaf() {
+		n.items = slices.Insert(n.items, i, data)
+		return true
+	}
+	if len(n.children[i].items) >= 2*degree-1 {
+		n.split(i, degree)
+		if n.items[i] == data {
+			return false
+		}
+		if n.items[i] < data {
+			i++
+		}
+	}
+	return n.children[i].insert(data, degree)
+}
+
+// split moves the median of the full child i up into n and the values above it into a new sibling.
+func (n *bnode[T]) split(i, degree int) {
+	child := n.children[i]
+	mid := child.items[degree-1]
+	right := &bnode[T]{items: slices.Clone(child.items[degree:])}
+	clear(child.items[degree-1:])
+	child.items = child.items[:degree-1]
+	if !child.leaf() {
+		right.children = slices.Clone(child.children[degree:])
+		clear(child.children[degree:]) // avoid memory leaks
+		child.children = child.children[:degree]
+	}
+	n.items = slices.Insert(n.items, i, mid)
+	n.children = slices.Insert(n.children, i+1, right)
+}
+
+// remove removes data from the subtree rooted at n.
+// Every node it descends into holds at least degree values, so a value can be taken without underflow.
+func (n *bnode[T]) remove(data T, degree int) bool {
+	i, found := n.find(data)
+	if n.leaf() {
+		if !found {
+			return false
+		}
+		n.items = slices.Delete(n.items, i, i+1)
+		return true
+	}
+	if found {
+		switch {
+		case len(n.children[i].items) >= degree:
+			pred := n.children[i].max()
+			n.items[i] = pred
+			return n.children[i].remove(pred, degree)
+		case len(n.children[i+1].items) >= degree:
+			succ := n.children[i+1].min()
+			n.items[i] = succ
+			return n.children[i+1].remove(succ, degree)
+		default:
+			n.merge(i)
+			return n.children[i].remove(data, degree)
+		}
+	}
+	if len(n.children[i].items) < degree {
+		i = n.fill(i, degree)
+	}
+	return n.children[i].remove(data, degree)
+}
+
+// fill makes child i hold at least degree values by borrowing from a sibling or merging with one.
+// It returns the index of the child that now covers the original range.
+func (n *bnode[T]) fill(i, degree int) int {
+	child := n.children[i]
+	if i > 0 && len(n.children[i-1].items) >= degree {
+		left := n.children[i-1]
+		child.items = slices.Insert(child.items, 0, n.items[i-1])
+		n.items[i-1] = left.items[len(left.items)-1]
+		left.items = slices.Delete(left.items, len(left.items)-1, len(left.items))
+		if !left.leaf() {
+			child.children = slices.Insert(child.children, 0, left.children[len(left.children)-1])
+			left.children = slices.Delete(left.children, len(left.children)-1, len(left.children))
+		}
+		return i
+	}
+	if i < len(n.items) && len(n.children[i+1].items) >= degree {
+		right := n.children[i+1]
+		child.items = append(child.items, n.items[i])
+		n.items[i] = right.items[0]
+		right.items = slices.Delete(right.items, 0, 1)
+		if !right.leaf() {
+			child.children = append(child.children, right.children[0])
+			right.children = slices.Delete(right.children, 0, 1)
+		}
+		return i
+	}
+	if i < len(n.items) {
+		n.merge(i)
+		return i
+	}
+	n.merge(i - 1)
+	return i - 1
+}
+
+// merge folds item i and child i+1 into child i.
+func (n *bnode[T]) merge(i int) {
+	left, right := n.children[i], n.children[i+1]
+	left.items = append(left.items, n.items[i])
+	left.items = append(left.items, right.items...)
+	left.children = append(left.children, right.children...)
+	n.items = slices.Delete(n.items, i, i+1)
+	n.children = slices.Delete(n.children, i+1, i+2)
+}
+
+func (n *bnode[T]) min() T {
+	for !n.leaf() {
+		n = n.children[0]
+	}
+	return n.items[0]
+}
+
+func (n *bnode[T]) max() T {
+	for !n.leaf() {
+		n = n.children[len(n.children)-1]
+	}
+	return n.items[len(n.items)-1]
+}
+
+func (n *bnode[T]) ascend(yield func(T) bool) bool {
+	for i, item := range n.items {
+		if !n.leaf() && !n.children[i].ascend(yield) {
+			return false
+		}
+		if !yield(item) {
+			return false
+		}
+	}
+	if !n.leaf() {
+		return n.children[len(n.items)].ascend(yield)
+	}
+	return true
+}
+
+// ascendRange yields the values in [from, to) and returns false once iteration must stop.
+func (n *bnode[T]) ascendRange(from, to T, yield func(T) bool) bool {
+	i, _ := n.find(from)
+	for ; i < len(n.items); i++ {
+		if !n.leaf() && !n.children[i].ascendRange(from, to, yield) {
+			return false
+		}
+		if n.items[i] >= to || !yield(n.items[i]) {
+			return false
+		}
+	}
+	if !n.leaf() {
+		return n.children[len(n.items)].ascendRange(from, to, yield)
+	}
+	return true
+}
+
+func (n *bnode[T]) clone() *bnode[T] {
+	c := &bnode[T]{items: slices.Clone(n.items)}
+	if !n.leaf() {
+		c.children = make([]*bnode[T], len(n.children))
+		for i, child := range n.children {
+			c.children[i] = child.clone()
+		}
+	}
+	return c
+}
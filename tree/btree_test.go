@@ -0,0 +1,260 @@
+package tree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBTree(t *testing.T) {
+	bt := NewBTree[int](2)
+	src := []int{5, 9, 4, 1, 8, 2, 7, 3, 6, 10, 5, 9}
+	for _, v := range src {
+		bt.Put(v)
+	}
+	assert.Equal(t, 10, bt.Len())
+	assert.True(t, bt.Has(7))
+	assert.False(t, bt.Has(11))
+	assert.EqualValues(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, slices.Collect(bt.Iter()))
+
+	min, ok := bt.Min()
+	assert.True(t, ok)
+	assert.Equal(t, 1, min)
+	max, ok := bt.Max()
+	assert.True(t, ok)
+	assert.Equal(t, 10, max)
+
+	bt.Del(6)
+	bt.Del(9)
+	bt.Del(4)
+	bt.Del(11)
+	assert.Equal(t, 7, bt.Len())
+	assert.EqualValues(t, []int{1, 2, 3, 5, 7, 8, 10}, slices.Collect(bt.Iter()))
+
+	for _, v := range src {
+		bt.Del(v)
+	}
+	assert.Equal(t, 0, bt.Len())
+	_, ok = bt.Min()
+	assert.False(t, ok)
+	_, ok = bt.Max()
+	assert.False(t, ok)
+	assert.Empty(t, slices.Collect(bt.Iter()))
+}
+
+func TestBTreeZeroValue(t *testing.T) {
+	var bt BTree[string]
+	assert.Equal(t, DefaultBTreeDegree, bt.Degree())
+	bt.Put("b")
+	bt.Put("a")
+	assert.EqualValues(t, []string{"a", "b"}, slices.Collect(bt.Iter()))
+	assert.Equal(t, DefaultBTreeDegree, NewBTree[int](1).Degree())
+}
+
+func TestBTreeRange(t *testing.T) {
+	bt := NewBTree[int](3)
+	for i := 0; i < 100; i += 2 {
+		bt.Put(i)
+	}
+	assert.EqualValues(t, []int{10, 12, 14, 16, 18}, slices.Collect(bt.Range(9, 20)))
+	assert.EqualValues(t, []int{0, 2}, slices.Collect(bt.Range(-5, 3)))
+	assert.EqualValues(t, []int{96, 98}, slices.Collect(bt.Range(96, 1000)))
+	assert.Empty(t, slices.Collect(bt.Range(20, 10)))
+	assert.Empty(t, slices.Collect(bt.Range(41, 42)))
+
+	// stop early
+	rlt := make([]int, 0)
+	for v := range bt.Range(0, 100) {
+		if v > 4 {
+			break
+		}
+		rlt = append(rlt, v)
+	}
+	assert.EqualValues(t, []int{0, 2, 4}, rlt)
+}
+
+func TestBTreeClone(t *testing.T) {
+	bt := NewBTree[int](2)
+	for i := 0; i < 20; i++ {
+		bt.Put(i)
+	}
+	c := bt.Clone()
+	for i := 0; i < 20; i += 2 {
+		c.Del(i)
+	}
+	c.Put(100)
+	assert.Equal(t, 20, bt.Len())
+	assert.Equal(t, 11, c.Len())
+	assert.False(t, bt.Has(100))
+	assert.True(t, bt.Has(0))
+	assert.EqualValues(t, []int{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 100}, slices.Collect(c.Iter()))
+}
+
+func TestBTreeRandom(t *testing.T) {
+	for _, degree := range []int{2, 3, 4, 32} {
+		bt := NewBTree[int](degree)
+		want := make(map[int]struct{})
+		for i := 0; i < 5000; i++ {
+			v := rand.Intn(1000)
+			if rand.Intn(3) == 0 {
+				bt.Del(v)
+				delete(want, v)
+			} else {
+				bt.Put(v)
+				want[v] = struct{}{}
+			}
+		}
+		keys := make([]int, 0, len(want))
+		for k := range want {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		assert.Equal(t, len(keys), bt.Len())
+		assert.EqualValues(t, keys, slices.Collect(bt.Iter()))
+		checkBTreeNode(t, bt.root, degree, true)
+	}
+}
+
+// checkBTreeNode verifies the occupancy invariants and returns the depth of the subtree.
+func checkBTreeNode(t *testing.T, n *bnode[int], degree int, root bool) int {
+	if n == nil {
+		return 0
+	}
+	if !root && len(n.items) < degree-1 {
+		t.Errorf("node has %d items, want at least %d", len(n.items), degree-1)
+	}
+	if len(n.items) > 2*degree-1 {
+		t.Errorf("node has %d items, want at most %d", len(n.items), 2*degree-1)
+	}
+	if n.leaf() {
+		return 1
+	}
+	if len(n.children) != len(n.items)+1 {
+		t.Errorf("node has %d children for %d items", len(n.children), len(n.items))
+	}
+	depth := checkBTreeNode(t, n.children[0], degree, false)
+	for _, child := range n.children[1:] {
+		if d := checkBTreeNode(t, child, degree, false); d != depth {
+			t.Errorf("unbalanced subtree depth %d, want %d", d, depth)
+		}
+	}
+	return depth + 1
+}
+
+const benchmarkTreeSize = 100000
+
+func benchmarkKeys() []int {
+	r := rand.New(rand.NewSource(1))
+	return r.Perm(benchmarkTreeSize)
+}
+
+func BenchmarkBTreePut(b *testing.B) {
+	keys := benchmarkKeys()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bt := NewBTree[int](DefaultBTreeDegree)
+		for _, k := range keys {
+			bt.Put(k)
+		}
+	}
+}
+
+func BenchmarkSearchTreePut(b *testing.B) {
+	keys := benchmarkKeys()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		st := &SearchTree[int]{}
+		for _, k := range keys {
+			st.Put(k)
+		}
+	}
+}
+
+// PersistentTree is the balanced (AVL) baseline; each Put copies the path to the key.
+func BenchmarkPersistentTreePut(b *testing.B) {
+	keys := benchmarkKeys()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pt := NewPersistentTree[int, struct{}]()
+		for _, k := range keys {
+			pt = pt.Put(k, struct{}{})
+		}
+	}
+}
+
+func BenchmarkBTreeFind(b *testing.B) {
+	keys := benchmarkKeys()
+	bt := NewBTree[int](DefaultBTreeDegree)
+	for _, k := range keys {
+		bt.Put(k)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bt.Has(keys[i%len(keys)])
+	}
+}
+
+func BenchmarkSearchTreeFind(b *testing.B) {
+	keys := benchmarkKeys()
+	st := &SearchTree[int]{}
+	for _, k := range keys {
+		st.Put(k)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		st.Find(keys[i%len(keys)])
+	}
+}
+
+func BenchmarkPersistentTreeFind(b *testing.B) {
+	keys := benchmarkKeys()
+	pt := NewPersistentTree[int, struct{}]()
+	for _, k := range keys {
+		pt = pt.Put(k, struct{}{})
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pt.Has(keys[i%len(keys)])
+	}
+}
+
+func BenchmarkBTreeIter(b *testing.B) {
+	keys := benchmarkKeys()
+	bt := NewBTree[int](DefaultBTreeDegree)
+	for _, k := range keys {
+		bt.Put(k)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for range bt.Iter() {
+		}
+	}
+}
+
+func BenchmarkSearchTreeIter(b *testing.B) {
+	keys := benchmarkKeys()
+	st := &SearchTree[int]{}
+	for _, k := range keys {
+		st.Put(k)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rlt := make([]int, 0, len(keys))
+		InOrder[int](st.root, &rlt)
+	}
+}
+
+func BenchmarkPersistentTreeIter(b *testing.B) {
+	keys := benchmarkKeys()
+	pt := NewPersistentTree[int, struct{}]()
+	for _, k := range keys {
+		pt = pt.Put(k, struct{}{})
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for range pt.Iter() {
+		}
+	}
+}
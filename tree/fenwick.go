@@ -0,0 +1,72 @@
+package tree
+
+import "github.com/danielhookx/xcontainer"
+
+// FenwickTree (binary indexed tree) maintains prefix sums of a numeric sequence
+// with point updates and range sums in O(log n).
+// All ranges are half-open, [l, r).
+type FenwickTree[T xcontainer.Int | xcontainer.Float] struct {
+	nodes []T // 1-based, nodes[0] is unused
+}
+
+// NewFenwickTree returns a Fenwick tree of n zero elements.
+func NewFenwickTree[T xcontainer.Int | xcontainer.Float](n int) *FenwickTree[T] {
+	return &FenwickTree[T]{
+		nodes: make([]T, n+1),
+	}
+}
+
+// BuildFenwickTree returns a Fenwick tree over the values of src in O(n).
+func BuildFenwickTree[T xcontainer.Int | xcontainer.Float](src []T) *FenwickTree[T] {
+	f := NewFenwickTree[T](len(src))
+	copy(f.nodes[1:], src)
+	for i := 1; i < len(f.nodes); i++ {
+		if j := i + i&-i; j < len(f.nodes) {
+			f.nodes[j] += f.nodes[i]
+		}
+	}
+	return f
+}
+
+// Len returns the number of elements of the sequence.
+func (f *FenwickTree[T]) Len() int { return len(f.nodes) - 1 }
+
+// Add adds delta to the element at index i.
+func (f *FenwickTree[T]) Add(i int, delta T) {
+	if i < 0 || i >= f.Len() {
+		panic("fenwick tree: index out of range")
+	}
+	for i++; i < len(f.nodes); i += i & -i {
+		f.nodes[i] += delta
+	}
+}
+
+// Set replaces the element at index i with v.
+func (f *FenwickTree[T]) Set(i int, v T) {
+	f.Add(i, v-f.Get(i))
+}
+
+// Get returns the element at index i.
+func (f *FenwickTree[T]) Get(i int) T {
+	return f.RangeSum(i, i+1)
+}
+
+// PrefixSum returns the sum of the elements in [0, i).
+func (f *FenwickTree[T]) PrefixSum(i int) T {
+	if i < 0 || i > f.Len() {
+		panic("fenwick tree: index out of range")
+	}
+	var sum T
+	for ; i > 0; i -= i & -i {
+		sum += f.nodes[i]
+	}
+	return sum
+}
+
+// RangeSum returns the sum of the elements in [l, r).
+func (f *FenwickTree[T]) RangeSum(l, r int) T {
+	if l > r {
+		panic("fenwick tree: range out of bounds")
+	}
+	return f.PrefixSum(r) - f.PrefixSum(l)
+}
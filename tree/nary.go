@@ -0,0 +1,206 @@
+package tree
+
+import (
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+// Node is a node of an n-ary tree with ordered children and a link to its parent.
+// A Node created by NewNode is the root of a new single-node tree.
+type Node[T any] struct {
+	val      T
+	parent   *Node[T]
+	children []*Node[T]
+	depth    int
+}
+
+// NewNode returns a new root node holding v.
+func NewNode[T any](v T) *Node[T] {
+	return &Node[T]{val: v}
+}
+
+// Val returns the value held by n.
+func (n *Node[T]) Val() T {
+	return n.val
+}
+
+// SetVal replaces the value held by n.
+func (n *Node[T]) SetVal(v T) {
+	n.val = v
+}
+
+// Parent returns the parent of n or nil if n is a root.
+func (n *Node[T]) Parent() *Node[T] {
+	return n.parent
+}
+
+// Children returns the children of n in insertion order.
+// The returned slice must not be modified.
+func (n *Node[T]) Children() []*Node[T] {
+	return n.children
+}
+
+// Depth returns the number of edges between n and the root of its tree.
+// The complexity is O(1).
+func (n *Node[T]) Depth() int {
+	return n.depth
+}
+
+// IsLeaf reports whether n has no children.
+func (n *Node[T]) IsLeaf() bool {
+	return len(n.children) == 0
+}
+
+// Root returns the root of the tree containing n.
+func (n *Node[T]) Root() *Node[T] {
+	for n.parent != nil {
+		n = n.parent
+	}
+	return n
+}
+
+// AddChild appends a new child holding v to n and returns it.
+func (n *Node[T]) AddChild(v T) *Node[T] {
+	child := &Node[T]{val: v, parent: n, depth: n.depth + 1}
+	n.children = append(n.children, child)
+	return child
+}
+
+// Attach moves the subtree rooted at child to the end of the children of n.
+// It panics if child is n or one of its ancestors.
+func (n *Node[T]) Attach(child *Node[T]) {
+	for p := n; p != nil; p = p.parent {
+		if p == child {
+			panic("tree: attach would create a cycle")
+		}
+	}
+	child.Detach()
+	child.parent = n
+	n.children = append(n.children, child)
+	child.resetDepth()
+}
+
+// Detach removes the subtree rooted at n from its parent, making n a root.
+// If n is already a root, the tree is not modified.
+func (n *Node[T]) Detach() {
+	p := n.parent
+	if p == nil {
+		return
+	}
+	if i := slices.Index(p.children, n); i >= 0 {
+		p.children = slices.Delete(p.children, i, i+1)
+	}
+	n.parent = nil
+	n.resetDepth()
+}
+
+// resetDepth recomputes the depth of every node in the subtree rooted at n.
+func (n *Node[T]) resetDepth() {
+	if n.parent == nil {
+		n.depth = 0
+	} else {
+		n.depth = n.parent.depth + 1
+	}
+	for c := range PreOrderIter(n, (*Node[T]).Children) {
+		if c != n {
+			c.depth = c.parent.depth + 1
+		}
+	}
+}
+
+// PathToRoot returns the nodes from n up to and including the root of its tree.
+func (n *Node[T]) PathToRoot() []*Node[T] {
+	path := make([]*Node[T], 0, n.depth+1)
+	for ; n != nil; n = n.parent {
+		path = append(path, n)
+	}
+	return path
+}
+
+// Iter returns an iterator over the subtree rooted at n in pre-order.
+func (n *Node[T]) Iter() iter.Seq[*Node[T]] {
+	return PreOrderIter(n, (*Node[T]).Children)
+}
+
+// LowestCommonAncestor returns the deepest node that is an ancestor of both a and b,
+// or nil if they belong to different trees. A node is an ancestor of itself.
+// The complexity is O(depth); use LCAIndex for repeated queries on a static tree.
+func LowestCommonAncestor[T any](a, b *Node[T]) *Node[T] {
+	for a.depth > b.depth {
+		a = a.parent
+	}
+	for b.depth > a.depth {
+		b = b.parent
+	}
+	for a != b {
+		a, b = a.parent, b.parent
+	}
+	return a
+}
+
+// LCAIndex answers lowest common ancestor queries on a tree in O(log depth) using binary lifting.
+// The index reflects the tree at the time it was built and must be rebuilt after the tree changes.
+type LCAIndex[T any] struct {
+	nodes map[*Node[T]]*lcaEntry[T]
+}
+
+type lcaEntry[T any] struct {
+	depth int
+	up    []*Node[T] // up[k] is the 2^k-th ancestor, for every 2^k <= depth
+}
+
+// NewLCAIndex builds an LCAIndex over the subtree rooted at root in O(n log depth).
+func NewLCAIndex[T any](root *Node[T]) *LCAIndex[T] {
+	x := &LCAIndex[T]{nodes: make(map[*Node[T]]*lcaEntry[T])}
+	for n := range BFSIter(root, (*Node[T]).Children) {
+		e := &lcaEntry[T]{}
+		if n != root {
+			pe := x.nodes[n.parent]
+			e.depth = pe.depth + 1
+			e.up = make([]*Node[T], bits.Len(uint(e.depth)))
+			e.up[0] = n.parent
+			for k := 1; k < len(e.up); k++ {
+				e.up[k] = x.nodes[e.up[k-1]].up[k-1]
+			}
+		}
+		x.nodes[n] = e
+	}
+	return x
+}
+
+// Query returns the lowest common ancestor of a and b, or nil if either is not in the index.
+func (x *LCAIndex[T]) Query(a, b *Node[T]) *Node[T] {
+	ea, ok := x.nodes[a]
+	if !ok {
+		return nil
+	}
+	eb, ok := x.nodes[b]
+	if !ok {
+		return nil
+	}
+	if ea.depth < eb.depth {
+		a, b, ea, eb = b, a, eb, ea
+	}
+	a = x.ancestor(a, ea.depth-eb.depth)
+	if a == b {
+		return a
+	}
+	for k := len(x.nodes[a].up) - 1; k >= 0; k-- {
+		ua, ub := x.nodes[a].up, x.nodes[b].up
+		if k < len(ua) && ua[k] != ub[k] {
+			a, b = ua[k], ub[k]
+		}
+	}
+	return x.nodes[a].up[0]
+}
+
+// ancestor returns the d-th ancestor of n.
+func (x *LCAIndex[T]) ancestor(n *Node[T], d int) *Node[T] {
+	for k := 0; d > 0; k, d = k+1, d>>1 {
+		if d&1 == 1 {
+			n = x.nodes[n].up[k]
+		}
+	}
+	return n
+}
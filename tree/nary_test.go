@@ -0,0 +1,158 @@
+package tree
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func nodeVals[T any](seq func(func(*Node[T]) bool)) []T {
+	rlt := make([]T, 0)
+	for n := range seq {
+		rlt = append(rlt, n.Val())
+	}
+	return rlt
+}
+
+func pathVals[T any](path []*Node[T]) []T {
+	rlt := make([]T, 0, len(path))
+	for _, n := range path {
+		rlt = append(rlt, n.Val())
+	}
+	return rlt
+}
+
+// newOrgChart builds
+//
+//	        ceo
+//	      /  |  \
+//	   cto  cfo  coo
+//	  /   \        \
+//	dev   ops    sales
+//	 |
+//	intern
+func newOrgChart() map[string]*Node[string] {
+	m := make(map[string]*Node[string])
+	m["ceo"] = NewNode("ceo")
+	m["cto"] = m["ceo"].AddChild("cto")
+	m["cfo"] = m["ceo"].AddChild("cfo")
+	m["coo"] = m["ceo"].AddChild("coo")
+	m["dev"] = m["cto"].AddChild("dev")
+	m["ops"] = m["cto"].AddChild("ops")
+	m["sales"] = m["coo"].AddChild("sales")
+	m["intern"] = m["dev"].AddChild("intern")
+	return m
+}
+
+func TestNode(t *testing.T) {
+	m := newOrgChart()
+	root := m["ceo"]
+	assert.Nil(t, root.Parent())
+	assert.Equal(t, m["cto"], m["dev"].Parent())
+	assert.Equal(t, 0, root.Depth())
+	assert.Equal(t, 3, m["intern"].Depth())
+	assert.True(t, m["cfo"].IsLeaf())
+	assert.False(t, m["cto"].IsLeaf())
+	assert.Len(t, root.Children(), 3)
+	assert.Equal(t, root, m["intern"].Root())
+	assert.EqualValues(t, []string{"intern", "dev", "cto", "ceo"}, pathVals(m["intern"].PathToRoot()))
+	assert.EqualValues(t, []string{"cto", "dev", "intern", "ops"}, nodeVals(m["cto"].Iter()))
+
+	m["sales"].SetVal("marketing")
+	assert.Equal(t, "marketing", m["sales"].Val())
+}
+
+func TestNodeAttachDetach(t *testing.T) {
+	m := newOrgChart()
+	m["cto"].Detach()
+	assert.Nil(t, m["cto"].Parent())
+	assert.Equal(t, 0, m["cto"].Depth())
+	assert.Equal(t, 2, m["intern"].Depth())
+	assert.EqualValues(t, []string{"ceo", "cfo", "coo", "sales"}, nodeVals(m["ceo"].Iter()))
+
+	m["sales"].Attach(m["cto"])
+	assert.Equal(t, m["sales"], m["cto"].Parent())
+	assert.Equal(t, 5, m["intern"].Depth())
+	assert.EqualValues(t, []string{"coo", "sales", "cto", "dev", "intern", "ops"}, nodeVals(m["coo"].Iter()))
+
+	m["ceo"].Attach(m["dev"])
+	assert.Equal(t, 2, m["intern"].Depth())
+	assert.EqualValues(t, []string{"ops"}, pathVals(m["cto"].Children()))
+	assert.Panics(t, func() { m["intern"].Attach(m["ceo"]) })
+	assert.Panics(t, func() { m["ceo"].Attach(m["ceo"]) })
+}
+
+func TestLowestCommonAncestor(t *testing.T) {
+	m := newOrgChart()
+	x := NewLCAIndex(m["ceo"])
+	cases := []struct {
+		a, b, want string
+	}{
+		{"intern", "ops", "cto"},
+		{"intern", "sales", "ceo"},
+		{"dev", "intern", "dev"},
+		{"cfo", "cfo", "cfo"},
+		{"ceo", "ops", "ceo"},
+		{"cfo", "coo", "ceo"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, LowestCommonAncestor(m[c.a], m[c.b]).Val(), "%s %s", c.a, c.b)
+		assert.Equal(t, c.want, x.Query(m[c.a], m[c.b]).Val(), "%s %s", c.a, c.b)
+		assert.Equal(t, c.want, x.Query(m[c.b], m[c.a]).Val(), "%s %s", c.b, c.a)
+	}
+
+	other := NewNode("other")
+	assert.Nil(t, LowestCommonAncestor(m["ops"], other))
+	assert.Nil(t, x.Query(m["ops"], other))
+}
+
+func TestLCAIndexRandom(t *testing.T) {
+	root := NewNode(0)
+	nodes := []*Node[int]{root}
+	for i := 1; i < 2000; i++ {
+		// bias towards recent nodes to get deep paths
+		p := nodes[len(nodes)-1-rand.Intn(min(len(nodes), 5))]
+		nodes = append(nodes, p.AddChild(i))
+	}
+	x := NewLCAIndex(root)
+	for i := 0; i < 1000; i++ {
+		a, b := nodes[rand.Intn(len(nodes))], nodes[rand.Intn(len(nodes))]
+		assert.Equal(t, LowestCommonAncestor(a, b), x.Query(a, b))
+	}
+}
+
+func TestTraversalIter(t *testing.T) {
+	m := newOrgChart()
+	children := (*Node[string]).Children
+	assert.EqualValues(t, []string{"ceo", "cto", "dev", "intern", "ops", "cfo", "coo", "sales"}, nodeVals(PreOrderIter(m["ceo"], children)))
+	assert.EqualValues(t, []string{"intern", "dev", "ops", "cto", "cfo", "sales", "coo", "ceo"}, nodeVals(PostOrderIter(m["ceo"], children)))
+	assert.EqualValues(t, []string{"ceo", "cto", "cfo", "coo", "dev", "ops", "sales", "intern"}, nodeVals(BFSIter(m["ceo"], children)))
+	assert.Empty(t, nodeVals(PreOrderIter((*Node[string])(nil), children)))
+
+	l1 := []string{"6"}
+	l2 := []string{"1", "3"}
+	l3 := []string{"9", "4", "2", "7"}
+	l4 := []string{"5", "nil", "nil", "nil", "8", "nil", "nil", "nil"}
+	var root TreeNodeI[int] = NewTree[int](l1, l2, l3, l4)
+	collect := func(seq func(func(TreeNodeI[int]) bool)) []int {
+		rlt := make([]int, 0)
+		for n := range seq {
+			rlt = append(rlt, n.Val())
+		}
+		return rlt
+	}
+	assert.EqualValues(t, []int{6, 1, 9, 5, 4, 3, 2, 8, 7}, collect(PreOrderIter(root, BinaryChildren[int])))
+	assert.EqualValues(t, []int{5, 9, 4, 1, 8, 2, 7, 3, 6}, collect(PostOrderIter(root, BinaryChildren[int])))
+	assert.EqualValues(t, []int{6, 1, 3, 9, 4, 2, 7, 5, 8}, collect(BFSIter(root, BinaryChildren[int])))
+
+	// stop early
+	rlt := make([]int, 0)
+	for n := range PostOrderIter(root, BinaryChildren[int]) {
+		if len(rlt) == 3 {
+			break
+		}
+		rlt = append(rlt, n.Val())
+	}
+	assert.EqualValues(t, []int{5, 9, 4}, rlt)
+}
@@ -0,0 +1,229 @@
+package tree
+
+import (
+	"iter"
+
+	"github.com/danielhookx/xcontainer"
+)
+
+// PersistentTree is an immutable ordered map implemented as an AVL tree with path copying.
+// Put and Del never modify the receiver: they return a new version that shares every
+// unchanged node with the old one, so an update costs O(log n) time and memory.
+// Because no version is ever mutated, any version may be read by many goroutines
+// concurrently while a writer derives new versions from it.
+// The zero value for PersistentTree is an empty tree ready to use.
+type PersistentTree[K xcontainer.Orderliness, V any] struct {
+	root *pnode[K, V]
+	len  int
+}
+
+type pnode[K xcontainer.Orderliness, V any] struct {
+	key         K
+	val         V
+	left, right *pnode[K, V]
+	height      int
+}
+
+// NewPersistentTree returns an empty PersistentTree.
+func NewPersistentTree[K xcontainer.Orderliness, V any]() *PersistentTree[K, V] {
+	return &PersistentTree[K, V]{}
+}
+
+// Len returns the number of key-value pairs in the tree.
+// The complexity is O(1).
+func (t *PersistentTree[K, V]) Len() int { return t.len }
+
+// Get retrieves a value from the tree by key.
+// Returns the value and a boolean indicating whether the key was found.
+func (t *PersistentTree[K, V]) Get(key K) (V, bool) {
+	for n := t.root; n != nil; {
+		switch {
+		case key < n.key:
+			n = n.left
+		case key > n.key:
+			n = n.right
+		default:
+			return n.val, true
+		}
+	}
+	return *new(V), false
+}
+
+// Has reports whether key is in the tree.
+func (t *PersistentTree[K, V]) Has(key K) bool {
+	_, ok := t.Get(key)
+	return ok
+}
+
+// Put returns a new version of the tree in which key maps to val.
+func (t *PersistentTree[K, V]) Put(key K, val V) *PersistentTree[K, V] {
+	root, added := t.root.put(key, val)
+	n := t.len
+	if added {
+		n++
+	}
+	return &PersistentTree[K, V]{root: root, len: n}
+}
+
+// Del returns a new version of the tree without key.
+// If key is not present, t itself is returned.
+func (t *PersistentTree[K, V]) Del(key K) *PersistentTree[K, V] {
+	root, removed := t.root.del(key)
+	if !removed {
+		return t
+	}
+	return &PersistentTree[K, V]{root: root, len: t.len - 1}
+}
+
+// Min returns the smallest key and its value.
+// The boolean is false if the tree is empty.
+func (t *PersistentTree[K, V]) Min() (K, V, bool) {
+	if t.root == nil {
+		return *new(K), *new(V), false
+	}
+	n := t.root.min()
+	return n.key, n.val, true
+}
+
+// Max returns the largest key and its value.
+// The boolean is false if the tree is empty.
+func (t *PersistentTree[K, V]) Max() (K, V, bool) {
+	n := t.root
+	if n == nil {
+		return *new(K), *new(V), false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.key, n.val, true
+}
+
+// Iter returns an iterator that yields key-value pairs in ascending key order.
+func (t *PersistentTree[K, V]) Iter() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.root.ascend(nil, nil, yield)
+	}
+}
+
+// Range returns an iterator that yields the key-value pairs with from <= key < to in ascending key order.
+func (t *PersistentTree[K, V]) Range(from, to K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.root.ascend(&from, &to, yield)
+	}
+}
+
+func (n *pnode[K, V]) h() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// mk returns a new node with the given content and a freshly computed height.
+func mk[K xcontainer.Orderliness, V any](key K, val V, left, right *pnode[K, V]) *pnode[K, V] {
+	return &pnode[K, V]{
+		key:    key,
+		val:    val,
+		left:   left,
+		right:  right,
+		height: max(left.h(), right.h()) + 1,
+	}
+}
+
+// balance is mk restoring the AVL invariant, assuming left and right differ in height by at most two.
+func balance[K xcontainer.Orderliness, V any](key K, val V, left, right *pnode[K, V]) *pnode[K, V] {
+	switch d := left.h() - right.h(); {
+	case d > 1:
+		if left.left.h() >= left.right.h() {
+			return mk(left.key, left.val, left.left, mk(key, val, left.right, right))
+		}
+		lr := left.right
+		return mk(lr.key, lr.val, mk(left.key, left.val, left.left, lr.left), mk(key, val, lr.right, right))
+	case d < -1:
+		if right.right.h() >= right.left.h() {
+			return mk(right.key, right.val, mk(key, val, left, right.left), right.right)
+		}
+		rl := right.left
+		return mk(rl.key, rl.val, mk(key, val, left, rl.left), mk(right.key, right.val, rl.right, right.right))
+	default:
+		return mk(key, val, left, right)
+	}
+}
+
+func (n *pnode[K, V]) put(key K, val V) (*pnode[K, V], bool) {
+	if n == nil {
+		return mk[K, V](key, val, nil, nil), true
+	}
+	switch {
+	case key < n.key:
+		left, added := n.left.put(key, val)
+		return balance(n.key, n.val, left, n.right), added
+	case key > n.key:
+		right, added := n.right.put(key, val)
+		return balance(n.key, n.val, n.left, right), added
+	default:
+		return &pnode[K, V]{key: key, val: val, left: n.left, right: n.right, height: n.height}, false
+	}
+}
+
+func (n *pnode[K, V]) del(key K) (*pnode[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	switch {
+	case key < n.key:
+		left, removed := n.left.del(key)
+		if !removed {
+			return n, false
+		}
+		return balance(n.key, n.val, left, n.right), true
+	case key > n.key:
+		right, removed := n.right.del(key)
+		if !removed {
+			return n, false
+		}
+		return balance(n.key, n.val, n.left, right), true
+	}
+	if n.left == nil {
+		return n.right, true
+	}
+	if n.right == nil {
+		return n.left, true
+	}
+	succ := n.right.min()
+	return balance(succ.key, succ.val, n.left, n.right.delMin()), true
+}
+
+func (n *pnode[K, V]) delMin() *pnode[K, V] {
+	if n.left == nil {
+		return n.right
+	}
+	return balance(n.key, n.val, n.left.delMin(), n.right)
+}
+
+func (n *pnode[K, V]) min() *pnode[K, V] {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+// ascend yields the pairs of the subtree rooted at n within the optional bounds [from, to)
+// and returns false once iteration must stop. The recursion is bounded by the tree height.
+func (n *pnode[K, V]) ascend(from, to *K, yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	if from == nil || *from < n.key {
+		if !n.left.ascend(from, to, yield) {
+			return false
+		}
+	}
+	if to != nil && n.key >= *to {
+		return false
+	}
+	if (from == nil || *from <= n.key) && !yield(n.key, n.val) {
+		return false
+	}
+	return n.right.ascend(from, to, yield)
+}
@@ -0,0 +1,179 @@
+package tree
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func persistentKeys[K int | string, V any](t *PersistentTree[K, V]) []K {
+	keys := make([]K, 0, t.Len())
+	for k := range t.Iter() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestPersistentTree(t *testing.T) {
+	var empty PersistentTree[int, string]
+	assert.Equal(t, 0, empty.Len())
+	_, _, ok := empty.Min()
+	assert.False(t, ok)
+	_, _, ok = empty.Max()
+	assert.False(t, ok)
+
+	v1 := empty.Put(5, "five").Put(9, "nine").Put(4, "four").Put(1, "one")
+	v2 := v1.Put(8, "eight").Put(2, "two").Put(9, "NINE")
+	v3 := v2.Del(4).Del(1)
+
+	assert.Equal(t, 0, empty.Len())
+	assert.EqualValues(t, []int{1, 4, 5, 9}, persistentKeys(v1))
+	assert.EqualValues(t, []int{1, 2, 4, 5, 8, 9}, persistentKeys(v2))
+	assert.EqualValues(t, []int{2, 5, 8, 9}, persistentKeys(v3))
+
+	v, ok := v1.Get(9)
+	assert.True(t, ok)
+	assert.Equal(t, "nine", v)
+	v, ok = v2.Get(9)
+	assert.True(t, ok)
+	assert.Equal(t, "NINE", v)
+	assert.True(t, v2.Has(4))
+	assert.False(t, v3.Has(4))
+	_, ok = v3.Get(100)
+	assert.False(t, ok)
+
+	k, v, ok := v2.Min()
+	assert.True(t, ok)
+	assert.Equal(t, 1, k)
+	assert.Equal(t, "one", v)
+	k, v, ok = v3.Max()
+	assert.True(t, ok)
+	assert.Equal(t, 9, k)
+	assert.Equal(t, "NINE", v)
+
+	assert.Same(t, v3, v3.Del(100))
+	assert.Equal(t, 4, v3.Len())
+	assert.Equal(t, 0, v3.Del(2).Del(5).Del(8).Del(9).Len())
+}
+
+func TestPersistentTreeRange(t *testing.T) {
+	pt := NewPersistentTree[int, int]()
+	for i := 0; i < 100; i += 2 {
+		pt = pt.Put(i, i*i)
+	}
+	keys := make([]int, 0)
+	for k, v := range pt.Range(9, 20) {
+		assert.Equal(t, k*k, v)
+		keys = append(keys, k)
+	}
+	assert.EqualValues(t, []int{10, 12, 14, 16, 18}, keys)
+
+	keys = keys[:0]
+	for k := range pt.Range(90, 1000) {
+		keys = append(keys, k)
+	}
+	assert.EqualValues(t, []int{90, 92, 94, 96, 98}, keys)
+
+	keys = keys[:0]
+	for k := range pt.Range(20, 10) {
+		keys = append(keys, k)
+	}
+	assert.Empty(t, keys)
+
+	keys = keys[:0]
+	for k := range pt.Iter() {
+		if k > 4 {
+			break
+		}
+		keys = append(keys, k)
+	}
+	assert.EqualValues(t, []int{0, 2, 4}, keys)
+}
+
+func TestPersistentTreeVersions(t *testing.T) {
+	versions := []*PersistentTree[int, int]{NewPersistentTree[int, int]()}
+	snapshots := [][]int{{}}
+	want := make(map[int]struct{})
+	for i := 0; i < 2000; i++ {
+		cur := versions[len(versions)-1]
+		v := rand.Intn(500)
+		if rand.Intn(3) == 0 {
+			cur = cur.Del(v)
+			delete(want, v)
+		} else {
+			cur = cur.Put(v, i)
+			want[v] = struct{}{}
+		}
+		versions = append(versions, cur)
+		keys := make([]int, 0, len(want))
+		for k := range want {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		snapshots = append(snapshots, keys)
+	}
+	for i, v := range versions {
+		assert.EqualValues(t, snapshots[i], persistentKeys(v))
+		assert.Equal(t, len(snapshots[i]), v.Len())
+		checkAVL(t, v.root)
+	}
+}
+
+// checkAVL verifies the cached heights and the balance of every node and returns the subtree height.
+func checkAVL(t *testing.T, n *pnode[int, int]) int {
+	if n == nil {
+		return 0
+	}
+	l, r := checkAVL(t, n.left), checkAVL(t, n.right)
+	if d := l - r; d > 1 || d < -1 {
+		t.Errorf("node %d is unbalanced: %d vs %d", n.key, l, r)
+	}
+	if h := max(l, r) + 1; h != n.height {
+		t.Errorf("node %d has height %d, want %d", n.key, n.height, h)
+	}
+	return n.height
+}
+
+func TestPersistentTreeConcurrentReaders(t *testing.T) {
+	var published atomic.Pointer[PersistentTree[int, int]]
+	published.Store(NewPersistentTree[int, int]())
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				snap := published.Load()
+				// every snapshot holds the keys 0..Len()-1 mapped to themselves
+				n := 0
+				for k, v := range snap.Iter() {
+					if k != n || v != n {
+						t.Errorf("snapshot of %d keys has %d=%d at %d", snap.Len(), k, v, n)
+						return
+					}
+					n++
+				}
+				if n != snap.Len() {
+					t.Errorf("snapshot yields %d keys, want %d", n, snap.Len())
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 2000; i++ {
+		published.Store(published.Load().Put(i, i))
+	}
+	close(stop)
+	wg.Wait()
+}
@@ -0,0 +1,175 @@
+package tree
+
+// SegmentTree answers range queries over a fixed-length sequence in O(log n).
+// Values are aggregated with a user-supplied associative combine function whose
+// identity element is returned for empty ranges.
+// Trees built with NewLazySegmentTree also support range updates in O(log n).
+// All ranges are half-open, [l, r).
+type SegmentTree[T any] struct {
+	n        int
+	nodes    []T
+	identity T
+	combine  func(a, b T) T
+
+	// lazy propagation, only set by NewLazySegmentTree
+	apply   func(agg, u T, length int) T
+	compose func(old, new T) T
+	lazy    []T
+	pending []bool
+}
+
+// NewSegmentTree builds a segment tree over a copy of src.
+func NewSegmentTree[T any](src []T, identity T, combine func(a, b T) T) *SegmentTree[T] {
+	s := &SegmentTree[T]{
+		n:        len(src),
+		identity: identity,
+		combine:  combine,
+	}
+	s.nodes = make([]T, 4*max(s.n, 1))
+	if s.n > 0 {
+		s.build(1, 0, s.n, src)
+	}
+	return s
+}
+
+// NewLazySegmentTree builds a segment tree over a copy of src that supports RangeUpdate.
+// apply returns the aggregate of a segment of the given length after update u is applied to
+// every element of it, and compose merges a pending update old with a newer update new.
+func NewLazySegmentTree[T any](src []T, identity T, combine func(a, b T) T,
+	apply func(agg, u T, length int) T, compose func(old, new T) T) *SegmentTree[T] {
+	s := NewSegmentTree(src, identity, combine)
+	s.apply = apply
+	s.compose = compose
+	s.lazy = make([]T, len(s.nodes))
+	s.pending = make([]bool, len(s.nodes))
+	return s
+}
+
+// Len returns the number of elements of the sequence.
+func (s *SegmentTree[T]) Len() int { return s.n }
+
+// Get returns the element at index i.
+func (s *SegmentTree[T]) Get(i int) T {
+	return s.Query(i, i+1)
+}
+
+// Set replaces the element at index i with v.
+func (s *SegmentTree[T]) Set(i int, v T) {
+	if i < 0 || i >= s.n {
+		panic("segment tree: index out of range")
+	}
+	s.set(1, 0, s.n, i, v)
+}
+
+// Query returns the combination of the elements in [l, r), or the identity if the range is empty.
+func (s *SegmentTree[T]) Query(l, r int) T {
+	s.checkRange(l, r)
+	if l == r {
+		return s.identity
+	}
+	return s.query(1, 0, s.n, l, r)
+}
+
+// RangeUpdate applies update u to every element in [l, r).
+// It panics if the tree was not built by NewLazySegmentTree.
+func (s *SegmentTree[T]) RangeUpdate(l, r int, u T) {
+	if s.apply == nil {
+		panic("segment tree: range update requires NewLazySegmentTree")
+	}
+	s.checkRange(l, r)
+	if l == r {
+		return
+	}
+	s.update(1, 0, s.n, l, r, u)
+}
+
+func (s *SegmentTree[T]) checkRange(l, r int) {
+	if l < 0 || r > s.n || l > r {
+		panic("segment tree: range out of bounds")
+	}
+}
+
+// build fills node x covering [lo, hi) from src.
+func (s *SegmentTree[T]) build(x, lo, hi int, src []T) {
+	if hi-lo == 1 {
+		s.nodes[x] = src[lo]
+		return
+	}
+	mid := lo + (hi-lo)/2
+	s.build(2*x, lo, mid, src)
+	s.build(2*x+1, mid, hi, src)
+	s.nodes[x] = s.combine(s.nodes[2*x], s.nodes[2*x+1])
+}
+
+func (s *SegmentTree[T]) set(x, lo, hi, i int, v T) {
+	if hi-lo == 1 {
+		s.nodes[x] = v
+		return
+	}
+	s.push(x, lo, hi)
+	mid := lo + (hi-lo)/2
+	if i < mid {
+		s.set(2*x, lo, mid, i, v)
+	} else {
+		s.set(2*x+1, mid, hi, i, v)
+	}
+	s.nodes[x] = s.combine(s.nodes[2*x], s.nodes[2*x+1])
+}
+
+func (s *SegmentTree[T]) query(x, lo, hi, l, r int) T {
+	if l <= lo && hi <= r {
+		return s.nodes[x]
+	}
+	s.push(x, lo, hi)
+	mid := lo + (hi-lo)/2
+	switch {
+	case r <= mid:
+		return s.query(2*x, lo, mid, l, r)
+	case l >= mid:
+		return s.query(2*x+1, mid, hi, l, r)
+	default:
+		return s.combine(s.query(2*x, lo, mid, l, r), s.query(2*x+1, mid, hi, l, r))
+	}
+}
+
+func (s *SegmentTree[T]) update(x, lo, hi, l, r int, u T) {
+	if l <= lo && hi <= r {
+		s.mark(x, lo, hi, u)
+		return
+	}
+	s.push(x, lo, hi)
+	mid := lo + (hi-lo)/2
+	if l < mid {
+		s.update(2*x, lo, mid, l, r, u)
+	}
+	if r > mid {
+		s.update(2*x+1, mid, hi, l, r, u)
+	}
+	s.nodes[x] = s.combine(s.nodes[2*x], s.nodes[2*x+1])
+}
+
+// mark applies u to node x covering [lo, hi) and defers it for the children.
+func (s *SegmentTree[T]) mark(x, lo, hi int, u T) {
+	s.nodes[x] = s.apply(s.nodes[x], u, hi-lo)
+	if hi-lo == 1 {
+		return
+	}
+	if s.pending[x] {
+		s.lazy[x] = s.compose(s.lazy[x], u)
+	} else {
+		s.lazy[x] = u
+		s.pending[x] = true
+	}
+}
+
+// push hands the pending update of node x covering [lo, hi) down to its children.
+func (s *SegmentTree[T]) push(x, lo, hi int) {
+	if s.pending == nil || !s.pending[x] {
+		return
+	}
+	mid := lo + (hi-lo)/2
+	s.mark(2*x, lo, mid, s.lazy[x])
+	s.mark(2*x+1, mid, hi, s.lazy[x])
+	s.lazy[x] = *new(T)
+	s.pending[x] = false
+}
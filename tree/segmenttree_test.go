@@ -0,0 +1,147 @@
+package tree
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sumOf(a, b int) int { return a + b }
+
+func TestSegmentTreeSum(t *testing.T) {
+	st := NewSegmentTree([]int{5, 9, 4, 1, 8, 2, 7}, 0, sumOf)
+	assert.Equal(t, 7, st.Len())
+	assert.Equal(t, 36, st.Query(0, 7))
+	assert.Equal(t, 14, st.Query(1, 4))
+	assert.Equal(t, 0, st.Query(3, 3))
+	assert.Equal(t, 8, st.Get(4))
+
+	st.Set(4, 0)
+	assert.Equal(t, 28, st.Query(0, 7))
+	assert.Equal(t, 9, st.Query(4, 7))
+
+	assert.Panics(t, func() { st.Query(2, 8) })
+	assert.Panics(t, func() { st.Set(7, 1) })
+	assert.Panics(t, func() { st.RangeUpdate(0, 1, 1) })
+
+	empty := NewSegmentTree([]int{}, 0, sumOf)
+	assert.Equal(t, 0, empty.Query(0, 0))
+}
+
+func TestSegmentTreeMinMax(t *testing.T) {
+	src := []float64{3.5, -1, 7, 2, 9.25, 0}
+	minST := NewSegmentTree(src, math.Inf(1), math.Min)
+	maxST := NewSegmentTree(src, math.Inf(-1), math.Max)
+	assert.Equal(t, -1.0, minST.Query(0, 6))
+	assert.Equal(t, 2.0, minST.Query(2, 5))
+	assert.Equal(t, 9.25, maxST.Query(0, 6))
+	assert.Equal(t, 7.0, maxST.Query(0, 4))
+	assert.Equal(t, math.Inf(1), minST.Query(1, 1))
+}
+
+func TestLazySegmentTree(t *testing.T) {
+	// range add, range sum
+	addSum := NewLazySegmentTree([]int{1, 2, 3, 4, 5, 6, 7, 8}, 0, sumOf,
+		func(agg, u int, length int) int { return agg + u*length },
+		sumOf)
+	addSum.RangeUpdate(2, 6, 10)
+	assert.Equal(t, 76, addSum.Query(0, 8))
+	assert.Equal(t, 29, addSum.Query(3, 5))
+	addSum.RangeUpdate(0, 3, -1)
+	assert.Equal(t, 73, addSum.Query(0, 8))
+	assert.Equal(t, 12, addSum.Get(2))
+	addSum.Set(2, 0)
+	assert.Equal(t, 61, addSum.Query(0, 8))
+
+	// range assign, range min
+	assignMin := NewLazySegmentTree([]int{4, 8, 1, 9, 3}, math.MaxInt, func(a, b int) int { return min(a, b) },
+		func(agg, u int, length int) int { return u },
+		func(old, new int) int { return new })
+	assignMin.RangeUpdate(1, 4, 6)
+	assert.Equal(t, 3, assignMin.Query(0, 5))
+	assert.Equal(t, 6, assignMin.Query(1, 4))
+	assignMin.RangeUpdate(2, 3, 2)
+	assert.Equal(t, 2, assignMin.Query(1, 4))
+	assert.Equal(t, 6, assignMin.Get(3))
+}
+
+func TestLazySegmentTreeRandom(t *testing.T) {
+	const n = 100
+	want := make([]int, n)
+	for i := range want {
+		want[i] = rand.Intn(100)
+	}
+	st := NewLazySegmentTree(want, 0, sumOf,
+		func(agg, u int, length int) int { return agg + u*length },
+		sumOf)
+	for i := 0; i < 1000; i++ {
+		l := rand.Intn(n)
+		r := l + rand.Intn(n-l+1)
+		switch rand.Intn(3) {
+		case 0:
+			u := rand.Intn(21) - 10
+			st.RangeUpdate(l, r, u)
+			for j := l; j < r; j++ {
+				want[j] += u
+			}
+		case 1:
+			v := rand.Intn(100)
+			st.Set(l, v)
+			want[l] = v
+		default:
+			sum := 0
+			for j := l; j < r; j++ {
+				sum += want[j]
+			}
+			assert.Equal(t, sum, st.Query(l, r))
+		}
+	}
+}
+
+func TestFenwickTree(t *testing.T) {
+	f := BuildFenwickTree([]int{5, 9, 4, 1, 8, 2, 7})
+	assert.Equal(t, 7, f.Len())
+	assert.Equal(t, 0, f.PrefixSum(0))
+	assert.Equal(t, 18, f.PrefixSum(3))
+	assert.Equal(t, 36, f.PrefixSum(7))
+	assert.Equal(t, 13, f.RangeSum(2, 5))
+	assert.Equal(t, 8, f.Get(4))
+
+	f.Add(4, -3)
+	assert.Equal(t, 5, f.Get(4))
+	assert.Equal(t, 33, f.PrefixSum(7))
+	f.Set(0, 1)
+	assert.Equal(t, 29, f.RangeSum(0, 7))
+
+	assert.Panics(t, func() { f.Add(7, 1) })
+	assert.Panics(t, func() { f.PrefixSum(8) })
+	assert.Panics(t, func() { f.RangeSum(3, 2) })
+
+	ff := NewFenwickTree[float64](4)
+	ff.Add(1, 0.5)
+	ff.Add(3, 1.25)
+	assert.Equal(t, 1.75, ff.RangeSum(0, 4))
+	assert.Equal(t, 0.5, ff.PrefixSum(3))
+}
+
+func TestFenwickTreeRandom(t *testing.T) {
+	const n = 100
+	want := make([]int64, n)
+	f := NewFenwickTree[int64](n)
+	for i := 0; i < 1000; i++ {
+		idx := rand.Intn(n)
+		d := int64(rand.Intn(21) - 10)
+		f.Add(idx, d)
+		want[idx] += d
+
+		l := rand.Intn(n)
+		r := l + rand.Intn(n-l+1)
+		var sum int64
+		for j := l; j < r; j++ {
+			sum += want[j]
+		}
+		assert.Equal(t, sum, f.RangeSum(l, r))
+	}
+}
@@ -2,10 +2,12 @@ package tree
 
 import (
 	"errors"
+	"iter"
 	"strconv"
 
 	"github.com/danielhookx/xcontainer"
 	xqueue "github.com/danielhookx/xcontainer/queue"
+	xstack "github.com/danielhookx/xcontainer/stack"
 )
 
 type TreeNodeI[T any] interface {
@@ -54,7 +56,7 @@ func TreeBFS[T any](root TreeNodeI[T]) [][]T {
 		l := queue.Len()
 		level := make([]T, 0)
 		for j := 0; j < l; j++ {
-			node := queue.DeQueue()
+			node, _ := queue.DeQueue()
 			level = append(level, node.Val())
 
 			if !xcontainer.IsNil[TreeNodeI[T]](node.Left()) {
@@ -70,6 +72,93 @@ func TreeBFS[T any](root TreeNodeI[T]) [][]T {
 	return result
 }
 
+// BinaryChildren returns the non-nil children of a binary tree node, left first.
+// It adapts TreeNodeI to PreOrderIter, PostOrderIter and BFSIter.
+func BinaryChildren[T any](n TreeNodeI[T]) []TreeNodeI[T] {
+	children := make([]TreeNodeI[T], 0, 2)
+	if l := n.Left(); !xcontainer.IsNil[TreeNodeI[T]](l) {
+		children = append(children, l)
+	}
+	if r := n.Right(); !xcontainer.IsNil[TreeNodeI[T]](r) {
+		children = append(children, r)
+	}
+	return children
+}
+
+// PreOrderIter returns an iterator over the tree rooted at root in pre-order.
+// children returns the children of a node in order, so the same traversal serves
+// binary trees (BinaryChildren) and n-ary trees ((*Node[T]).Children).
+func PreOrderIter[N any](root N, children func(N) []N) iter.Seq[N] {
+	return func(yield func(N) bool) {
+		if xcontainer.IsNil(root) {
+			return
+		}
+		stack := xstack.NewStack[N]()
+		stack.Push(root)
+		for stack.Len() > 0 {
+			node := stack.Pop()
+			if !yield(node) {
+				return
+			}
+			c := children(node)
+			for i := len(c) - 1; i >= 0; i-- {
+				stack.Push(c[i])
+			}
+		}
+	}
+}
+
+// PostOrderIter returns an iterator over the tree rooted at root in post-order.
+// See PreOrderIter for the meaning of children.
+func PostOrderIter[N any](root N, children func(N) []N) iter.Seq[N] {
+	type frame struct {
+		node     N
+		children []N
+		next     int
+	}
+	return func(yield func(N) bool) {
+		if xcontainer.IsNil(root) {
+			return
+		}
+		stack := xstack.NewStack[*frame]()
+		stack.Push(&frame{node: root, children: children(root)})
+		for stack.Len() > 0 {
+			f := stack.Pop()
+			if f.next < len(f.children) {
+				child := f.children[f.next]
+				f.next++
+				stack.Push(f)
+				stack.Push(&frame{node: child, children: children(child)})
+				continue
+			}
+			if !yield(f.node) {
+				return
+			}
+		}
+	}
+}
+
+// BFSIter returns an iterator over the tree rooted at root in level order.
+// See PreOrderIter for the meaning of children.
+func BFSIter[N any](root N, children func(N) []N) iter.Seq[N] {
+	return func(yield func(N) bool) {
+		if xcontainer.IsNil(root) {
+			return
+		}
+		queue := xqueue.NewQueue[N]()
+		queue.EnQueue(root)
+		for queue.Len() > 0 {
+			node, _ := queue.DeQueue()
+			if !yield(node) {
+				return
+			}
+			for _, c := range children(node) {
+				queue.EnQueue(c)
+			}
+		}
+	}
+}
+
 type TreeNode[T any] struct {
 	val   T
 	left  *TreeNode[T]
@@ -140,8 +229,8 @@ func NewTree[T any](level ...[]string) *TreeNode[T] {
 	for queue.Len() > 0 {
 		l := queue.Len()
 		for i := 0; i < l; i++ {
-			node := queue.DeQueue()
-			index := indexQueue.DeQueue()
+			node, _ := queue.DeQueue()
+			index, _ := indexQueue.DeQueue()
 
 			leftChildIndex := index*2 + 1
 			rightChildIndex := index*2 + 2
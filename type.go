@@ -19,6 +19,10 @@ type Orderliness interface {
 
 func IsNil[T any](t T) bool {
 	v := reflect.ValueOf(t)
+	// A nil interface has no dynamic value at all
+	if !v.IsValid() {
+		return true
+	}
 	kind := v.Kind()
 	// Must be one of these types to be nillable
 	return (kind == reflect.Ptr ||
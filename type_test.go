@@ -0,0 +1,36 @@
+package xcontainer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type node interface {
+	Val() int
+}
+
+type ptrNode struct{ val int }
+
+func (n *ptrNode) Val() int { return n.val }
+
+func TestIsNil(t *testing.T) {
+	// a nil interface has no dynamic type at all
+	var empty node
+	assert.True(t, IsNil(empty))
+	assert.True(t, IsNil[any](nil))
+
+	// a typed nil pointer inside an interface is nil too
+	var p *ptrNode
+	assert.True(t, IsNil[node](p))
+	assert.True(t, IsNil(p))
+	assert.False(t, IsNil[node](&ptrNode{}))
+
+	var m map[string]int
+	assert.True(t, IsNil(m))
+
+	// values that cannot be nil never are
+	assert.False(t, IsNil(0))
+	assert.False(t, IsNil(""))
+	assert.False(t, IsNil[any](struct{}{}))
+}